@@ -6,6 +6,7 @@ import (
 	"github.com/shy-robin/gochat/config"
 	"github.com/shy-robin/gochat/internal/db"
 	"github.com/shy-robin/gochat/internal/router"
+	"github.com/shy-robin/gochat/internal/service"
 	"github.com/shy-robin/gochat/pkg/common"
 	"github.com/shy-robin/gochat/pkg/global/log"
 )
@@ -22,6 +23,9 @@ func main() {
 	// 初始化数据库
 	db.InitMysqlDB()
 
+	// 启动后台任务
+	service.StartJobs()
+
 	// 初始化路由
 	ginServer := router.NewRouter()
 	common.SetupCustomValidator(ginServer)
@@ -7,11 +7,18 @@ import (
 )
 
 type TomlConfig struct {
-	AppName string
-	Log     LogConfig
-	MySQL   MySQLConfig
-	Api     ApiConfig
-	Jwt     JWTConfig
+	AppName   string
+	Log       LogConfig
+	MySQL     MySQLConfig
+	Api       ApiConfig
+	Jwt       JWTConfig
+	Presence  PresenceConfig
+	Message   MessageConfig
+	Friend    FriendConfig
+	RateLimit RateLimitConfig
+	Discovery DiscoveryConfig
+	Group     GroupConfig
+	Mail      MailConfig
 }
 
 // 日志存储地址
@@ -40,8 +47,77 @@ type ApiConfig struct {
 
 // jwt 配置
 type JWTConfig struct {
-	Secret     string
-	ExpireTime int
+	Secret string
+	// Access Token 有效期，单位: 分钟
+	AccessTokenTtl int
+	// Refresh Token 有效期，每次刷新后重新计算，单位: 天
+	RefreshTokenTtl int
+	// 已吊销 Token 的存储方式：memory 或 database，多实例部署时需要使用 database
+	RevocationStore string
+}
+
+// 在线状态配置
+type PresenceConfig struct {
+	// 超过该时间没有心跳，则认为用户处于离开状态，单位: 秒
+	AwayTimeout int
+}
+
+// 消息配置
+type MessageConfig struct {
+	// 消息发送后允许编辑的时间，单位: 秒
+	EditWindow int
+	// 群聊中每条消息最多允许的不同表情数
+	MaxGroupReactions int
+}
+
+// 好友配置
+type FriendConfig struct {
+	// 好友申请的有效期，单位: 天
+	RequestTtl int
+}
+
+// 接口限流配置，单位: 次/分钟
+type RateLimitConfig struct {
+	Search    int
+	Discovery int
+	// 两步验证登录，按客户端 IP 限流
+	TwoFactor int
+}
+
+// 联系人发现配置
+type DiscoveryConfig struct {
+	// 计算邮箱哈希时拼接在邮箱前面的盐，客户端需要使用相同的盐
+	// 修改后需要清空 users.email_hash，重启时会按新的盐重新计算
+	Salt string
+	// 每次请求最多上传的哈希数
+	BatchLimit int
+}
+
+// 群聊配置
+type GroupConfig struct {
+	// 入群申请被拒绝后，再次申请需要等待的时间，单位: 小时
+	JoinRequestCooldown int
+}
+
+// 邮件配置
+type MailConfig struct {
+	// 发送方式：smtp 或 outbox，outbox 将邮件写入本地目录，用于开发和测试
+	Driver string
+	// 发件人，如 gochat <no-reply@example.com>
+	From string
+	// SMTP 服务器，服务器支持 STARTTLS 时会自动启用
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// outbox 模式下邮件的保存目录
+	OutboxPath string
+	// 验证邮箱链接的前缀，Token 拼接在后面
+	VerifyUrl string
+	// 验证邮箱链接的有效期，单位: 小时
+	VerifyTokenTtl int
+	// 两次发送验证邮件的最小间隔，单位: 秒
+	ResendCooldown int
 }
 
 var c TomlConfig
@@ -31,7 +31,7 @@ func initDB() {
 
 	// 拼接下 dsn 参数, dsn 格式可以参考上面的语法，这里使用 Sprintf 动态拼接 dsn 参数，因为一般数据库连接参数，我们都是保存在配置文件里面，需要从配置文件加载参数，然后拼接dsn。
 	// 构造一个不带数据库名的 DSN，用于连接到 MySQL 服务器本身
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=utf8&parseTime=True&loc=Local&timeout=%s", username, password, host, port, timeout)
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=utf8mb4&parseTime=True&loc=Local&timeout=%s", username, password, host, port, timeout)
 
 	// 第一次连接：连接到 MySQL 服务器，而不是特定的数据库
 	tempDB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
@@ -48,9 +48,11 @@ func initDB() {
 	}
 
 	// 构造带数据库名的完整 DSN
-	appDsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8&parseTime=True&loc=Local&timeout=%s", username, password, host, port, dbName, timeout)
+	appDsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local&timeout=%s", username, password, host, port, dbName, timeout)
 	db, err = gorm.Open(mysql.Open(appDsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// 将数据库方言错误转换为 gorm 错误，例如唯一索引冲突转换为 gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 	if err != nil {
 		panic(fmt.Errorf("连接应用数据库 %s 失败: %w", dbName, err))
@@ -66,11 +68,90 @@ func initDB() {
 func migrateDB() {
 	log.Logger.Info("数据库自动迁移")
 
-	err := db.AutoMigrate(&model.User{})
+	err := db.AutoMigrate(
+		&model.User{},
+		&model.Conversation{},
+		&model.Message{},
+		&model.MessageRevision{},
+		&model.MessageHidden{},
+		&model.MessageReaction{},
+		&model.ThreadParticipant{},
+		&model.Group{},
+		&model.GroupMember{},
+		&model.GroupInvite{},
+		&model.GroupJoinRequest{},
+		&model.GroupRole{},
+		&model.GroupBan{},
+		&model.GroupModerationLog{},
+		&model.MessageReceipt{},
+		&model.UserSetting{},
+		&model.FriendRequest{},
+		&model.Friendship{},
+		&model.UserBlock{},
+		&model.RefreshToken{},
+		&model.Session{},
+		&model.UserTwoFactor{},
+		&model.RecoveryCode{},
+		&model.LoginChallenge{},
+		&model.EmailVerification{},
+		&model.RevokedToken{},
+	)
 	if err != nil {
 		log.Logger.Error("自动迁移数据库失败", log.Any("err", err))
 	}
 
+	// 表情列使用二进制排序规则，AutoMigrate 不会比较排序规则，需要单独修改已有的列
+	for _, column := range []struct {
+		model any
+		table string
+		field string
+		name  string
+	}{
+		{&model.MessageReaction{}, "message_reactions", "Emoji", "emoji"},
+		{&model.User{}, "users", "StatusEmoji", "status_emoji"},
+	} {
+		var collation string
+		err = db.Raw(
+			"SELECT COLLATION_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?",
+			column.table, column.name,
+		).Scan(&collation).Error
+		if err == nil && collation != "utf8mb4_bin" {
+			err = db.Migrator().AlterColumn(column.model, column.field)
+		}
+		if err != nil {
+			log.Logger.Error("修改表情列排序规则失败", log.String("column", column.name), log.Any("err", err))
+		}
+	}
+
+	// 旧版本的 show_last_seen 开关迁移为 last_seen_visibility
+	if db.Migrator().HasColumn(&model.UserSetting{}, "show_last_seen") {
+		err = db.Model(&model.UserSetting{}).
+			Where("show_last_seen = ?", false).
+			Update("last_seen_visibility", model.VisibilityNobody).Error
+		if err == nil {
+			err = db.Migrator().DropColumn(&model.UserSetting{}, "show_last_seen")
+		}
+		if err != nil {
+			log.Logger.Error("迁移最后在线时间设置失败", log.Any("err", err))
+		}
+	}
+
+	// 旧版本注册时即写入邮箱哈希，清除邮箱未验证用户的哈希，避免冒用邮箱被联系人发现
+	err = db.Model(&model.User{}).
+		Where("email_verified = ? AND email_hash <> ''", false).
+		Update("email_hash", "").Error
+	if err != nil {
+		log.Logger.Error("清除未验证邮箱的哈希失败", log.Any("err", err))
+	}
+
+	// 为邮箱已验证的用户补全邮箱哈希，与 service 中 emailHash 的计算方式保持一致
+	err = db.Model(&model.User{}).
+		Where("email <> '' AND email_verified = ? AND (email_hash IS NULL OR email_hash = '')", true).
+		Update("email_hash", gorm.Expr("SHA2(CONCAT(?, LOWER(TRIM(email))), 256)", config.GetConfig().Discovery.Salt)).Error
+	if err != nil {
+		log.Logger.Error("补全邮箱哈希失败", log.Any("err", err))
+	}
+
 	log.Logger.Info("数据库自动迁移完成")
 }
 
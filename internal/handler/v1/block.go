@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		屏蔽用户
+// @Description	屏蔽指定用户，重复屏蔽时忽略。屏蔽后对方不能发起单聊、发送好友申请、查看自己的在线状态和完整资料，双方的好友关系同时解除
+// @Tags			blocks
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"用户 uuid"
+// @Success		200	{object}	common.SuccessResponse		"屏蔽成功"
+// @Failure		400	{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/blocks/{id} [put]
+func BlockUser(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.BlockSvc.Block(userId, ctx.Param("id")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		取消屏蔽
+// @Description	取消屏蔽指定用户，未屏蔽时忽略
+// @Tags			blocks
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"用户 uuid"
+// @Success		200	{object}	common.SuccessResponse		"取消成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/blocks/{id} [delete]
+func UnblockUser(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.BlockSvc.Unblock(userId, ctx.Param("id")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		获取屏蔽名单
+// @Description	分页获取当前用户屏蔽的人，按屏蔽时间倒序排列
+// @Tags			blocks
+// @Accept			json
+// @Produce		json
+// @Param			page		query		int								false	"页码，默认 1"
+// @Param			pageSize	query		int								false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListBlockedUsersResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/blocks [get]
+func GetBlockedUsers(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	users, meta, err := service.BlockSvc.ListBlocked(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(users),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
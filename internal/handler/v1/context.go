@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// currentUserId 获取 JWTAuthMiddleware 写入 Context 的当前用户 uuid
+func currentUserId(ctx *gin.Context) (string, *common.ServiceError) {
+	userIdValue, ok := ctx.Get("userId")
+
+	if !ok {
+		return "", common.ErrTokenUserIdNotFound
+	}
+
+	return userIdValue.(string), nil
+}
+
+// optionalUserId 获取 OptionalJWTAuthMiddleware 写入 Context 的当前用户 uuid，未登录时为空
+func optionalUserId(ctx *gin.Context) string {
+	userId, _ := ctx.Get("userId")
+	userIdString, _ := userId.(string)
+	return userIdString
+}
+
+// currentClaims 获取 JWTAuthMiddleware 写入 Context 的 Token 载荷
+func currentClaims(ctx *gin.Context) (*common.Claims, *common.ServiceError) {
+	claims, ok := ctx.Get("claims")
+
+	if !ok {
+		return nil, common.ErrTokenUserIdNotFound
+	}
+
+	return claims.(*common.Claims), nil
+}
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		获取会话消息历史
+// @Description	基于游标分页获取会话消息，返回的消息按时间正序排列。before 和 after 互斥，都不传时返回最新的消息；meta 中返回 hasMore 以及继续翻页的 nextCursor，nextCursor 仅在 hasMore 为 true 时返回
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"会话 uuid"
+// @Param			before	query		string						false	"向前翻页的游标"
+// @Param			after	query		string						false	"向后翻页的游标"
+// @Param			limit	query		int							false	"条数，默认 20，最大 100"
+// @Success		200		{object}	dto.ListMessagesResponse	"获取成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/conversations/{id}/messages [get]
+func GetConversationMessages(
+	ctx *gin.Context,
+	req dto.ListMessagesRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages, meta, err := service.MessageSvc.ListConversationMessages(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(messages),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
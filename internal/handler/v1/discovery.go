@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		获取联系人发现参数
+// @Description	获取计算邮箱哈希使用的算法、盐以及单次上传的数量上限。哈希计算方式：hex(sha256(salt + 去除首尾空格并转为小写的邮箱))
+// @Tags			discovery
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	dto.DiscoveryConfigResponse	"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/contact-discovery [get]
+func GetDiscoveryConfig(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		service.DiscoverySvc.GetConfig(),
+	), nil
+}
+
+// @Summary		发现联系人
+// @Description	上传通讯录中邮箱的哈希，返回匹配到的用户。不会返回关闭了联系人发现的用户以及存在屏蔽关系的用户，接口有频率限制
+// @Tags			discovery
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.DiscoverContactsRequest		true	"请求参数"
+// @Success		200		{object}	dto.DiscoverContactsResponse	"匹配成功"
+// @Failure		400		{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/contact-discovery [post]
+func DiscoverContacts(
+	ctx *gin.Context,
+	req dto.DiscoverContactsRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := service.DiscoverySvc.Discover(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		contacts,
+	), nil
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type BlockedUserData struct {
+	Uuid     string    `json:"uuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Username string    `json:"username" example:"robin"`
+	Nickname string    `json:"nickname" example:"robin"`
+	Avatar   string    `json:"avatar,omitempty" example:"https://avatars.githubusercontent.com/u/123456?v=4"`
+	BlockAt  time.Time `json:"blockAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type ListBlockedUsersResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []BlockedUserData
+	Meta   common.PageMeta
+}
@@ -0,0 +1,27 @@
+package dto
+
+// 分页参数的默认值
+const (
+	DefaultPage     = 1
+	DefaultPageSize = 20
+)
+
+// PageRequest 是基于页码的分页参数
+type PageRequest struct {
+	Page     int `json:"page" form:"page" example:"1" binding:"omitempty,min=1"`
+	PageSize int `json:"pageSize" form:"pageSize" example:"20" binding:"omitempty,min=1,max=100"`
+}
+
+// Normalize 为未传入的分页参数设置默认值
+func (this *PageRequest) Normalize() {
+	if this.Page == 0 {
+		this.Page = DefaultPage
+	}
+	if this.PageSize == 0 {
+		this.PageSize = DefaultPageSize
+	}
+}
+
+func (this *PageRequest) Offset() int {
+	return (this.Page - 1) * this.PageSize
+}
@@ -0,0 +1,31 @@
+package dto
+
+// DiscoveryConfigData 是客户端计算邮箱哈希所需的参数
+type DiscoveryConfigData struct {
+	// 哈希算法：hex(sha256(salt + 去除首尾空格并转为小写的邮箱))
+	Algorithm  string `json:"algorithm" example:"sha256"`
+	Salt       string `json:"salt" example:"gochat-discovery"`
+	BatchLimit int    `json:"batchLimit" example:"500"`
+}
+
+type DiscoveryConfigResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   DiscoveryConfigData
+}
+
+// DiscoverContactsRequest 上传通讯录中邮箱的哈希，数量不能超过 batchLimit
+type DiscoverContactsRequest struct {
+	Hashes []string `json:"hashes" example:"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" binding:"required,min=1"`
+}
+
+type DiscoveredContactData struct {
+	Hash     string `json:"hash" example:"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"`
+	Uuid     string `json:"uuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Username string `json:"username" example:"robin"`
+	Nickname string `json:"nickname" example:"robin"`
+}
+
+type DiscoverContactsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []DiscoveredContactData
+}
@@ -0,0 +1,21 @@
+package dto
+
+// VerifyEmailRequest 使用验证邮件中的 Token 验证邮箱
+type VerifyEmailRequest struct {
+	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..." binding:"required"`
+}
+
+func (this *VerifyEmailRequest) MaskSecrets() {
+	// 数据脱敏
+	this.Token = "******"
+}
+
+type VerifyEmailData struct {
+	// 验证通过后生效的邮箱
+	Email string `json:"email" example:"robin@test.com"`
+}
+
+type VerifyEmailResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   VerifyEmailData
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type SendFriendRequestRequest struct {
+	ReceiverUuid string `json:"receiverUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb" binding:"required"`
+	// 附言
+	Message string `json:"message" example:"我是 robin" binding:"omitempty,max=200"`
+}
+
+// ListFriendRequestsRequest 查询待处理的好友申请，direction 默认为 incoming（收到的申请）
+type ListFriendRequestsRequest struct {
+	PageRequest
+	Direction string `json:"direction" form:"direction" example:"incoming" binding:"omitempty,oneof=incoming outgoing"`
+}
+
+type FriendRequestData struct {
+	Uuid          string     `json:"uuid" example:"3c6f1f0e-8b7a-4d2c-9e1f-2a3b4c5d6e7f"`
+	RequesterUuid string     `json:"requesterUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	AddresseeUuid string     `json:"addresseeUuid" example:"5a1d9c2e-6b3f-4e8a-9d7c-1f2e3a4b5c6d"`
+	Message       string     `json:"message" example:"我是 robin"`
+	Status        string     `json:"status" example:"pending"`
+	ExpiresAt     time.Time  `json:"expiresAt" example:"2025-11-30T15:53:56.811"`
+	RespondedAt   *time.Time `json:"respondedAt,omitempty" example:"2025-11-24T10:00:00.000"`
+	CreateAt      time.Time  `json:"createAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type FriendData struct {
+	Uuid     string    `json:"uuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Username string    `json:"username" example:"robin"`
+	Nickname string    `json:"nickname" example:"robin"`
+	Avatar   string    `json:"avatar,omitempty" example:"https://avatars.githubusercontent.com/u/123456?v=4"`
+	Presence string    `json:"presence" example:"online"`
+	FriendAt time.Time `json:"friendAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type FriendRequestResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   FriendRequestData
+}
+
+type ListFriendRequestsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []FriendRequestData
+	Meta   common.PageMeta
+}
+
+type ListFriendsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []FriendData
+	Meta   common.PageMeta
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type CreateGroupRequest struct {
+	Name        string   `json:"name" example:"gochat 交流群" binding:"required,min=1,max=50"`
+	Avatar      string   `json:"avatar" example:"https://avatars.githubusercontent.com/u/123456?v=4" binding:"omitempty,url"`
+	MemberUuids []string `json:"memberUuids" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb" binding:"omitempty,max=100"`
+	// 私有群只能通过邀请链接或入群申请加入
+	Private bool `json:"private" example:"false"`
+}
+
+type ModifyGroupRequest struct {
+	Name    string `json:"name" example:"gochat 交流群" binding:"omitempty,min=1,max=50"`
+	Avatar  string `json:"avatar" example:"https://avatars.githubusercontent.com/u/123456?v=4" binding:"omitempty,url"`
+	Private *bool  `json:"private" example:"true"`
+}
+
+type AddGroupMembersRequest struct {
+	MemberUuids []string `json:"memberUuids" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb" binding:"required,min=1,max=100"`
+}
+
+type GroupData struct {
+	Uuid             string    `json:"uuid" example:"9d3c2b1a-7e6f-4a5b-8c9d-0e1f2a3b4c5d"`
+	Name             string    `json:"name" example:"gochat 交流群"`
+	Avatar           string    `json:"avatar" example:"https://avatars.githubusercontent.com/u/123456?v=4"`
+	OwnerUuid        string    `json:"ownerUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	ConversationUuid string    `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	MemberCount      int       `json:"memberCount" example:"3"`
+	Private          bool      `json:"private" example:"false"`
+	CreateAt         time.Time `json:"createAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type GroupMemberData struct {
+	Uuid        string   `json:"uuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Username    string   `json:"username" example:"robin"`
+	Nickname    string   `json:"nickname" example:"robin"`
+	Avatar      string   `json:"avatar,omitempty" example:"https://avatars.githubusercontent.com/u/123456?v=4"`
+	Role        string   `json:"role" example:"member"`
+	Permissions []string `json:"permissions" example:"send_message,send_media"`
+	// 禁言结束时间，未被禁言时不返回
+	MutedUntil *time.Time `json:"mutedUntil,omitempty" example:"2025-11-23T16:53:56.811"`
+	JoinAt     time.Time  `json:"joinAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type GroupResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   GroupData
+}
+
+type ListGroupsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupData
+	Meta   common.PageMeta
+}
+
+type ListGroupMembersResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupMemberData
+	Meta   common.PageMeta
+}
+
+// GroupMembersChangedData 是群成员变更事件的数据
+type GroupMembersChangedData struct {
+	GroupUuid    string   `json:"groupUuid"`
+	OperatorUuid string   `json:"operatorUuid"`
+	MemberUuids  []string `json:"memberUuids"`
+}
+
+// GroupMemberRoleChangedData 是 WebSocket 事件 group.member_role_changed 的数据
+type GroupMemberRoleChangedData struct {
+	GroupUuid    string `json:"groupUuid"`
+	OperatorUuid string `json:"operatorUuid"`
+	UserUuid     string `json:"userUuid"`
+	Role         string `json:"role"`
+}
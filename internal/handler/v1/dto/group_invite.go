@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type CreateGroupInviteRequest struct {
+	// 过期时间，为空表示永不过期
+	ExpiresAt *time.Time `json:"expiresAt" example:"2025-11-30T15:53:56Z"`
+	// 最大使用次数，为空表示不限制
+	MaxUses int `json:"maxUses" example:"10" binding:"omitempty,min=1,max=10000"`
+	// 开启后通过链接入群需要管理员审批
+	RequireApproval bool `json:"requireApproval" example:"false"`
+}
+
+type GroupInviteData struct {
+	Uuid            string     `json:"uuid" example:"2f7e5d1c-4b3a-4c2d-9e8f-7a6b5c4d3e2f"`
+	Token           string     `json:"token" example:"q3Jd8sLx0VbN2mKf7YwZtA"`
+	GroupUuid       string     `json:"groupUuid" example:"9d3c2b1a-7e6f-4a5b-8c9d-0e1f2a3b4c5d"`
+	CreatorUuid     string     `json:"creatorUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	ExpiresAt       *time.Time `json:"expiresAt" example:"2025-11-30T15:53:56Z"`
+	MaxUses         int        `json:"maxUses" example:"10"`
+	UseCount        int        `json:"useCount" example:"3"`
+	RequireApproval bool       `json:"requireApproval" example:"false"`
+	CreateAt        time.Time  `json:"createAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type GroupInviteResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   GroupInviteData
+}
+
+type ListGroupInvitesResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupInviteData
+	Meta   common.PageMeta
+}
+
+// GroupInvitePreviewData 是加入前通过邀请链接看到的群信息
+type GroupInvitePreviewData struct {
+	GroupUuid       string     `json:"groupUuid" example:"9d3c2b1a-7e6f-4a5b-8c9d-0e1f2a3b4c5d"`
+	Name            string     `json:"name" example:"gochat 交流群"`
+	Avatar          string     `json:"avatar" example:"https://avatars.githubusercontent.com/u/123456?v=4"`
+	MemberCount     int        `json:"memberCount" example:"3"`
+	RequireApproval bool       `json:"requireApproval" example:"false"`
+	ExpiresAt       *time.Time `json:"expiresAt" example:"2025-11-30T15:53:56Z"`
+}
+
+type GroupInvitePreviewResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   GroupInvitePreviewData
+}
+
+type GroupJoinRequestData struct {
+	Uuid         string     `json:"uuid" example:"7c1e2d3f-4a5b-4c6d-8e9f-0a1b2c3d4e5f"`
+	GroupUuid    string     `json:"groupUuid" example:"9d3c2b1a-7e6f-4a5b-8c9d-0e1f2a3b4c5d"`
+	UserUuid     string     `json:"userUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	InviteUuid   string     `json:"inviteUuid,omitempty" example:"2f7e5d1c-4b3a-4c2d-9e8f-7a6b5c4d3e2f"`
+	Message      string     `json:"message" example:"我是 robin"`
+	Status       string     `json:"status" example:"pending"`
+	ReviewerUuid string     `json:"reviewerUuid,omitempty" example:""`
+	ReviewedAt   *time.Time `json:"reviewedAt,omitempty" example:"2025-11-23T15:53:56.811"`
+	CreateAt     time.Time  `json:"createAt" example:"2025-11-23T15:53:56.811"`
+}
+
+// CreateGroupJoinRequestRequest 申请加入私有群
+type CreateGroupJoinRequestRequest struct {
+	Message string `json:"message" example:"我是 robin" binding:"max=200"`
+}
+
+// ReviewGroupJoinRequestsRequest 批量审批入群申请，已处理的申请会被忽略
+type ReviewGroupJoinRequestsRequest struct {
+	RequestUuids []string `json:"requestUuids" example:"7c1e2d3f-4a5b-4c6d-8e9f-0a1b2c3d4e5f" binding:"required,min=1,max=100"`
+	// approve 通过，reject 拒绝
+	Action string `json:"action" example:"approve" binding:"required,oneof=approve reject"`
+}
+
+type ListGroupJoinRequestsBatchResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupJoinRequestData
+}
+
+type GroupJoinRequestResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   GroupJoinRequestData
+}
+
+type ListGroupJoinRequestsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupJoinRequestData
+	Meta   common.PageMeta
+}
+
+// JoinGroupData 是通过邀请链接入群的结果
+// status 为 joined 时返回 group，为 pending 时返回待审批的 joinRequest
+type JoinGroupData struct {
+	Status      string                `json:"status" example:"joined"`
+	Group       *GroupData            `json:"group,omitempty"`
+	JoinRequest *GroupJoinRequestData `json:"joinRequest,omitempty"`
+}
+
+type JoinGroupResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   JoinGroupData
+}
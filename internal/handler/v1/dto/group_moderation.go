@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// BanGroupMemberRequest 禁止用户加入群聊，用户在群内时会被移出
+type BanGroupMemberRequest struct {
+	UserUuid string `json:"userUuid" example:"5a1d9c2e-6b3f-4e8a-9d7c-1f2e3a4b5c6d" binding:"required"`
+	Reason   string `json:"reason" example:"发布广告" binding:"max=200"`
+}
+
+// MuteGroupMemberRequest 禁言成员，重复禁言会覆盖之前的禁言结束时间
+type MuteGroupMemberRequest struct {
+	// 禁言时长（秒），60 秒到 30 天
+	Duration int    `json:"duration" example:"3600" binding:"required,min=60,max=2592000"`
+	Reason   string `json:"reason" example:"刷屏" binding:"max=200"`
+}
+
+type GroupBanData struct {
+	UserUuid     string    `json:"userUuid" example:"5a1d9c2e-6b3f-4e8a-9d7c-1f2e3a4b5c6d"`
+	OperatorUuid string    `json:"operatorUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Reason       string    `json:"reason" example:"发布广告"`
+	CreateAt     time.Time `json:"createAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type GroupBanResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   GroupBanData
+}
+
+type ListGroupBansResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupBanData
+	Meta   common.PageMeta
+}
+
+type GroupModerationLogData struct {
+	// 操作：kick、ban、unban、mute、unmute、mute_expired（禁言自动到期）
+	Action     string `json:"action" example:"mute"`
+	TargetUuid string `json:"targetUuid" example:"5a1d9c2e-6b3f-4e8a-9d7c-1f2e3a4b5c6d"`
+	// 操作人，action 为 mute_expired 时为空
+	OperatorUuid string     `json:"operatorUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Reason       string     `json:"reason" example:"刷屏"`
+	MutedUntil   *time.Time `json:"mutedUntil,omitempty" example:"2025-11-23T16:53:56.811"`
+	CreateAt     time.Time  `json:"createAt" example:"2025-11-23T15:53:56.811"`
+}
+
+type ListGroupModerationLogsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupModerationLogData
+	Meta   common.PageMeta
+}
+
+// GroupMemberMutedData 是 WebSocket 事件 group.member_muted 的数据，mutedUntil 为空表示已解除禁言
+type GroupMemberMutedData struct {
+	GroupUuid    string     `json:"groupUuid"`
+	OperatorUuid string     `json:"operatorUuid"`
+	UserUuid     string     `json:"userUuid"`
+	MutedUntil   *time.Time `json:"mutedUntil"`
+}
+
+// SystemMessageContent 是群管理操作生成的系统消息内容，序列化为 JSON 后保存在消息的 content 中
+type SystemMessageContent struct {
+	Action       string     `json:"action"`
+	TargetUuid   string     `json:"targetUuid"`
+	OperatorUuid string     `json:"operatorUuid"`
+	Reason       string     `json:"reason,omitempty"`
+	MutedUntil   *time.Time `json:"mutedUntil,omitempty"`
+}
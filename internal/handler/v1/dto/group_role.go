@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+type GroupRoleData struct {
+	Name string `json:"name" example:"moderator"`
+	// 是否为内置角色，内置角色不能修改
+	Builtin     bool     `json:"builtin" example:"true"`
+	Permissions []string `json:"permissions" example:"send_message,send_media,pin_message,remove_members"`
+}
+
+type GroupRoleResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   GroupRoleData
+}
+
+type ListGroupRolesResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []GroupRoleData
+}
+
+// CreateGroupRoleRequest 创建自定义角色，名称不能与内置角色重复
+type CreateGroupRoleRequest struct {
+	Role        string   `json:"role" example:"editor" binding:"required,max=20"`
+	Permissions []string `json:"permissions" example:"send_message,edit_group" binding:"required"`
+}
+
+type ModifyGroupRoleRequest struct {
+	Permissions []string `json:"permissions" example:"send_message,pin_message" binding:"required"`
+}
+
+// AssignGroupRoleRequest 修改成员角色，可以是除 owner 以外的内置角色或自定义角色
+type AssignGroupRoleRequest struct {
+	Role string `json:"role" example:"moderator" binding:"required,max=20"`
+}
+
+// MessagePinData 是消息置顶事件的数据
+type MessagePinData struct {
+	ConversationUuid string     `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	MessageUuid      string     `json:"messageUuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	OperatorUuid     string     `json:"operatorUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	PinnedAt         *time.Time `json:"pinnedAt,omitempty" example:"2025-11-23T15:55:02.104"`
+}
@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type SendMessageRequest struct {
+	Type    string `json:"type" example:"text" binding:"omitempty,oneof=text image file"`
+	Content string `json:"content" example:"你好" binding:"required,max=5000"`
+	// 引用回复的消息 uuid
+	ReplyToUuid string `json:"replyToUuid" example:""`
+	// 话题根消息 uuid，传入时消息作为话题回复，不出现在会话主时间线中
+	ThreadRootUuid string `json:"threadRootUuid" example:""`
+}
+
+type EditMessageRequest struct {
+	Content string `json:"content" example:"你好呀" binding:"required,max=5000"`
+}
+
+// ListMessagesRequest 是消息历史的游标分页参数
+// before 和 after 互斥：before 向前翻页（更早的消息），after 向后翻页（更新的消息），都不传时返回最新的消息
+type ListMessagesRequest struct {
+	Before string `json:"before" form:"before" example:"eyJ0IjoxNzYzOTExMTE5MDAwMDAwMDAwLCJpZCI6NDJ9"`
+	After  string `json:"after" form:"after" example:""`
+	Limit  int    `json:"limit" form:"limit" example:"20" binding:"omitempty,min=1,max=100"`
+}
+
+type MessageData struct {
+	Uuid             string `json:"uuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	ConversationUuid string `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	SenderUuid       string `json:"senderUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	// text、image、file 或 system，system 为群管理操作生成的系统消息，content 为 JSON
+	Type string `json:"type" example:"text"`
+	// 已撤回的消息内容为空
+	Content  string     `json:"content" example:"你好"`
+	CreateAt time.Time  `json:"createAt" example:"2025-11-23T15:53:56.811"`
+	EditedAt *time.Time `json:"editedAt,omitempty" example:"2025-11-23T15:55:02.104"`
+	Deleted  bool       `json:"deleted" example:"false"`
+	// 表情回应的聚合结果，仅在消息历史中返回
+	Reactions []ReactionData `json:"reactions,omitempty"`
+	// 引用的消息
+	ReplyTo *QuotedMessageData `json:"replyTo,omitempty"`
+	// 所属话题的根消息 uuid
+	ThreadRootUuid string `json:"threadRootUuid,omitempty" example:""`
+	// 话题信息，仅话题根消息有回复时返回
+	Thread *ThreadData `json:"thread,omitempty"`
+	// 置顶时间，未置顶时不返回
+	PinnedAt *time.Time `json:"pinnedAt,omitempty" example:"2025-11-23T15:55:02.104"`
+}
+
+// QuotedMessageData 是被引用消息的摘要
+type QuotedMessageData struct {
+	Uuid       string `json:"uuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	SenderUuid string `json:"senderUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Type       string `json:"type" example:"text"`
+	Content    string `json:"content" example:"你好"`
+	Deleted    bool   `json:"deleted" example:"false"`
+}
+
+type ThreadData struct {
+	ReplyCount       int        `json:"replyCount" example:"3"`
+	LastReplyAt      *time.Time `json:"lastReplyAt" example:"2025-11-23T15:55:02.104"`
+	ParticipantUuids []string   `json:"participantUuids"`
+}
+
+// ThreadUpdatedData 是 thread.updated 事件的数据
+type ThreadUpdatedData struct {
+	ConversationUuid string     `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	ThreadRootUuid   string     `json:"threadRootUuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	ReplyCount       int        `json:"replyCount" example:"3"`
+	LastReplyAt      *time.Time `json:"lastReplyAt" example:"2025-11-23T15:55:02.104"`
+}
+
+// MessageDeletedData 是 message.deleted 事件的数据
+// scope 为 me 时只推送给自己的其他设备
+type MessageDeletedData struct {
+	ConversationUuid string `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	MessageUuid      string `json:"messageUuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	Scope            string `json:"scope" example:"everyone"`
+}
+
+type MessageRevisionData struct {
+	Content string `json:"content" example:"你好"`
+	// 该版本被替换的时间
+	EditedAt time.Time `json:"editedAt" example:"2025-11-23T15:55:02.104"`
+}
+
+type SendMessageResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   MessageData
+}
+
+type ListMessagesResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []MessageData
+	Meta   common.CursorMeta
+}
+
+type EditMessageResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   MessageData
+}
+
+type MessageRevisionsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []MessageRevisionData
+}
+
+type PinnedMessagesResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []MessageData
+}
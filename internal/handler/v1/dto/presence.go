@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// HeartbeatRequest 是客户端定期发送的 heartbeat 事件
+type HeartbeatRequest struct {
+	// 客户端检测到用户空闲（如页面切到后台）时为 true
+	Away bool `json:"away" example:"false"`
+}
+
+// PresenceData 是 presence.changed 事件的数据
+type PresenceData struct {
+	UserUuid   string     `json:"userUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	State      string     `json:"state" example:"online"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty" example:"2025-11-23T15:53:56.811"`
+}
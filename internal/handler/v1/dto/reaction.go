@@ -0,0 +1,30 @@
+package dto
+
+type AddReactionRequest struct {
+	Emoji string `json:"emoji" example:"👍" binding:"required,max=16"`
+}
+
+// ReactionData 是消息上某个表情的聚合结果
+type ReactionData struct {
+	Emoji string `json:"emoji" example:"👍"`
+	Count int    `json:"count" example:"3"`
+	// 当前用户是否回应过该表情
+	Reacted bool `json:"reacted" example:"true"`
+}
+
+// ReactionChangedData 是 message.reaction 事件的数据
+type ReactionChangedData struct {
+	ConversationUuid string `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	MessageUuid      string `json:"messageUuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	UserUuid         string `json:"userUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Emoji            string `json:"emoji" example:"👍"`
+	// true 为添加，false 为移除
+	Added bool `json:"added" example:"true"`
+	// 变更后该表情的回应人数
+	Count int `json:"count" example:"3"`
+}
+
+type ReactionChangedResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   ReactionChangedData
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// AckReceiptRequest 确认消息已送达或已读，该消息及之前的所有消息都会被标记
+// 既可以通过 HTTP 接口提交，也可以通过 WebSocket 事件 receipt.ack 提交
+type AckReceiptRequest struct {
+	// 仅在 WebSocket 事件中使用，HTTP 接口从路径中获取
+	ConversationUuid string `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	MessageUuid      string `json:"messageUuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11" binding:"required"`
+	Status           string `json:"status" example:"read" binding:"required,oneof=delivered read"`
+}
+
+// ReceiptData 是回执状态变更的数据，同时作为 message.receipt 事件推送给消息发送者
+type ReceiptData struct {
+	ConversationUuid string    `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	UserUuid         string    `json:"userUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	MessageUuid      string    `json:"messageUuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	Status           string    `json:"status" example:"read"`
+	At               time.Time `json:"at" example:"2025-11-23T15:53:56.811"`
+}
+
+type AckReceiptResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   ReceiptData
+}
+
+type SeenByData struct {
+	Uuid     string    `json:"uuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+	Username string    `json:"username" example:"robin"`
+	Nickname string    `json:"nickname" example:"robin"`
+	Avatar   string    `json:"avatar,omitempty" example:"https://avatars.githubusercontent.com/u/123456?v=4"`
+	ReadAt   time.Time `json:"readAt" example:"2025-11-23T15:53:56.811"`
+}
+
+// MessageReceiptsData 是消息回执的汇总，SeenBy 按已读时间分页
+type MessageReceiptsData struct {
+	MessageUuid    string       `json:"messageUuid" example:"6f1c7c5e-2f6a-4a55-9a83-0e1f0a7a3f11"`
+	RecipientCount int          `json:"recipientCount" example:"10"`
+	DeliveredCount int64        `json:"deliveredCount" example:"8"`
+	ReadCount      int64        `json:"readCount" example:"5"`
+	SeenBy         []SeenByData `json:"seenBy"`
+}
+
+type MessageReceiptsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   MessageReceiptsData
+	Meta   common.PageMeta
+}
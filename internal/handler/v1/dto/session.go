@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type SessionData struct {
+	Uuid       string    `json:"uuid" example:"3f8e2a71-5c4d-4b9e-8a6f-2d1c0b9e8f7a"`
+	DeviceName string    `json:"deviceName" example:"Robin 的 iPhone"`
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"`
+	Ip         string    `json:"ip" example:"203.0.113.10"`
+	CreateAt   time.Time `json:"createAt" example:"2025-11-23T15:53:56.811"`
+	LastUsedAt time.Time `json:"lastUsedAt" example:"2025-11-24T09:12:03.215"`
+	// 是否为当前请求使用的会话
+	Current bool `json:"current" example:"true"`
+}
+
+type ListSessionsResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   []SessionData
+}
@@ -0,0 +1,28 @@
+package dto
+
+type UserSettingData struct {
+	ReadReceipts bool `json:"readReceipts" example:"true"`
+	Searchable   bool `json:"searchable" example:"true"`
+	Discoverable bool `json:"discoverable" example:"true"`
+	// 资料字段的可见范围：everyone、contacts（仅好友）、nobody
+	EmailVisibility    string `json:"emailVisibility" example:"contacts"`
+	AvatarVisibility   string `json:"avatarVisibility" example:"everyone"`
+	LastSeenVisibility string `json:"lastSeenVisibility" example:"everyone"`
+	BioVisibility      string `json:"bioVisibility" example:"everyone"`
+}
+
+// ModifyUserSettingRequest 使用指针区分「未传入」和「设置为 false」
+type ModifyUserSettingRequest struct {
+	ReadReceipts       *bool   `json:"readReceipts" example:"false"`
+	Searchable         *bool   `json:"searchable" example:"false"`
+	Discoverable       *bool   `json:"discoverable" example:"false"`
+	EmailVisibility    *string `json:"emailVisibility" example:"nobody" binding:"omitempty,oneof=everyone contacts nobody"`
+	AvatarVisibility   *string `json:"avatarVisibility" example:"contacts" binding:"omitempty,oneof=everyone contacts nobody"`
+	LastSeenVisibility *string `json:"lastSeenVisibility" example:"nobody" binding:"omitempty,oneof=everyone contacts nobody"`
+	BioVisibility      *string `json:"bioVisibility" example:"contacts" binding:"omitempty,oneof=everyone contacts nobody"`
+}
+
+type UserSettingResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   UserSettingData
+}
@@ -0,0 +1,73 @@
+package dto
+
+type TwoFactorStatusData struct {
+	Enabled bool `json:"enabled" example:"true"`
+	// 剩余可用的恢复码数量
+	RecoveryCodesRemaining int64 `json:"recoveryCodesRemaining" example:"10"`
+}
+
+type TwoFactorStatusResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   TwoFactorStatusData
+}
+
+type TwoFactorEnrollData struct {
+	// base32 编码的密钥，无法扫描二维码时手动输入
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	// otpauth:// 格式的配置地址，客户端渲染为二维码供验证器 App 扫描
+	ProvisioningUri string `json:"provisioningUri" example:"otpauth://totp/gochat:robin?algorithm=SHA1&digits=6&issuer=gochat&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+type TwoFactorEnrollResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   TwoFactorEnrollData
+}
+
+type ConfirmTwoFactorRequest struct {
+	// 验证器 App 生成的 6 位验证码
+	Code string `json:"code" example:"123456" binding:"required,max=32"`
+}
+
+func (this *ConfirmTwoFactorRequest) MaskSecrets() {
+	// 数据脱敏
+	this.Code = "******"
+}
+
+type RecoveryCodesData struct {
+	// 恢复码只在开启时返回一次，每个恢复码只能使用一次
+	RecoveryCodes []string `json:"recoveryCodes" example:"k3d9-x2mq-7pfa-h4tz"`
+}
+
+type RecoveryCodesResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   RecoveryCodesData
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" example:"123456" binding:"required"`
+	// 验证码或恢复码
+	Code string `json:"code" example:"123456" binding:"required,max=32"`
+}
+
+func (this *DisableTwoFactorRequest) SetPassword() {
+	// 数据脱敏
+	this.Password = "******"
+}
+
+func (this *DisableTwoFactorRequest) MaskSecrets() {
+	// 数据脱敏
+	this.Code = "******"
+}
+
+// VerifyTwoFactorRequest 使用登录时返回的 challengeToken 和验证码换取正式的 Token
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challengeToken" example:"Zt4nW8pQ1rVx6kLm3sYc9aHd2fJe7uBg5oTi0yNq4wE" binding:"required"`
+	// 验证码或恢复码
+	Code string `json:"code" example:"123456" binding:"required,max=32"`
+}
+
+func (this *VerifyTwoFactorRequest) MaskSecrets() {
+	// 数据脱敏
+	this.ChallengeToken = "******"
+	this.Code = "******"
+}
@@ -0,0 +1,12 @@
+package dto
+
+// TypingRequest 是 typing.start、typing.stop 事件的数据
+type TypingRequest struct {
+	ConversationUuid string `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22" binding:"required"`
+}
+
+// TypingData 是 typing.started、typing.stopped 事件的数据
+type TypingData struct {
+	ConversationUuid string `json:"conversationUuid" example:"0b8a7f0e-3c1d-4a8e-8d4f-5b9e2c6a1d22"`
+	UserUuid         string `json:"userUuid" example:"db376853-8f93-41f9-9a44-3c5ad8eedbbb"`
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		重新发送验证邮件
+// @Description	有待验证的新邮箱时发送到新邮箱，否则发送到尚未验证的当前邮箱。两次发送之间需要间隔 mail.resendCooldown 秒
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	common.SuccessResponse		"发送成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/me/email/resend [post]
+func ResendEmailVerification(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.EmailSvc.Resend(userId); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		验证邮箱
+// @Description	使用验证邮件中的 Token 验证邮箱，无需登录。Token 只能使用一次，验证的是新邮箱时会同时替换当前邮箱
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.VerifyEmailRequest		true	"请求参数"
+// @Success		200		{object}	dto.VerifyEmailResponse		"验证成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Router			/email/verify [post]
+func VerifyEmail(
+	ctx *gin.Context,
+	req dto.VerifyEmailRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	res, err := service.EmailSvc.Verify(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		res,
+	), nil
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		发送好友申请
+// @Description	向指定用户发送好友申请，并实时推送 friend.request_received 事件给对方。如果对方已经向自己发送了待处理的申请，则直接成为好友
+// @Tags			friends
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.SendFriendRequestRequest	true	"请求参数"
+// @Success		201		{object}	dto.FriendRequestResponse		"发送成功"
+// @Failure		400		{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/friend-requests [post]
+func SendFriendRequest(
+	ctx *gin.Context,
+	req dto.SendFriendRequestRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := service.FriendSvc.SendRequest(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		request,
+	), nil
+}
+
+// @Summary		获取好友申请
+// @Description	分页获取待处理的好友申请，direction 为 incoming 时获取收到的申请（默认），为 outgoing 时获取发出的申请
+// @Tags			friends
+// @Accept			json
+// @Produce		json
+// @Param			direction	query		string							false	"incoming、outgoing"
+// @Param			page		query		int								false	"页码，默认 1"
+// @Param			pageSize	query		int								false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListFriendRequestsResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/friend-requests [get]
+func GetFriendRequests(
+	ctx *gin.Context,
+	req dto.ListFriendRequestsRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests, meta, err := service.FriendSvc.ListRequests(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(requests),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		接受好友申请
+// @Description	接受收到的好友申请，并实时推送 friend.request_updated 事件给申请双方
+// @Tags			friends
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"好友申请 uuid"
+// @Success		200	{object}	dto.FriendRequestResponse	"接受成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/friend-requests/{id}/accept [post]
+func AcceptFriendRequest(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := service.FriendSvc.AcceptRequest(userId, ctx.Param("id"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		request,
+	), nil
+}
+
+// @Summary		拒绝好友申请
+// @Description	拒绝收到的好友申请，并实时推送 friend.request_updated 事件给申请双方
+// @Tags			friends
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"好友申请 uuid"
+// @Success		200	{object}	dto.FriendRequestResponse	"拒绝成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/friend-requests/{id}/decline [post]
+func DeclineFriendRequest(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := service.FriendSvc.DeclineRequest(userId, ctx.Param("id"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		request,
+	), nil
+}
+
+// @Summary		撤销好友申请
+// @Description	撤销自己发出的好友申请，并实时推送 friend.request_updated 事件给申请双方
+// @Tags			friends
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"好友申请 uuid"
+// @Success		200	{object}	dto.FriendRequestResponse	"撤销成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/friend-requests/{id} [delete]
+func CancelFriendRequest(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := service.FriendSvc.CancelRequest(userId, ctx.Param("id"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		request,
+	), nil
+}
+
+// @Summary		获取好友列表
+// @Description	分页获取好友列表，按成为好友的时间倒序排列
+// @Tags			friends
+// @Accept			json
+// @Produce		json
+// @Param			page		query		int							false	"页码，默认 1"
+// @Param			pageSize	query		int							false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListFriendsResponse		"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/friends [get]
+func GetFriends(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	friends, meta, err := service.FriendSvc.ListFriends(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(friends),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
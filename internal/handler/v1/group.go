@@ -0,0 +1,414 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		创建群聊
+// @Description	创建群聊，创建者自动成为群主
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.CreateGroupRequest		true	"请求参数"
+// @Success		201		{object}	dto.GroupResponse			"创建成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups [post]
+func CreateGroup(
+	ctx *gin.Context,
+	req dto.CreateGroupRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := service.GroupSvc.CreateGroup(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		group,
+	), nil
+}
+
+// @Summary		获取我的群聊
+// @Description	分页获取当前用户加入的群聊
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			page		query		int							false	"页码，默认 1"
+// @Param			pageSize	query		int							false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListGroupsResponse		"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups [get]
+func GetGroups(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	groups, meta, err := service.GroupSvc.ListMyGroups(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(groups),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		获取群资料
+// @Description	获取群资料，仅群成员可查看
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"群 uuid"
+// @Success		200	{object}	dto.GroupResponse			"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id} [get]
+func GetGroup(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := service.GroupSvc.GetGroup(userId, ctx.Param("id"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		group,
+	), nil
+}
+
+// @Summary		修改群资料
+// @Description	修改群名称、群头像或是否私有，需要 edit_group 权限
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			request	body		dto.ModifyGroupRequest		true	"请求参数"
+// @Success		200		{object}	dto.GroupResponse			"修改成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id} [patch]
+func ModifyGroup(
+	ctx *gin.Context,
+	req dto.ModifyGroupRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := service.GroupSvc.ModifyGroup(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		group,
+	), nil
+}
+
+// @Summary		获取群成员
+// @Description	按入群顺序分页获取群成员，仅群成员可查看
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string							true	"群 uuid"
+// @Param			page		query		int								false	"页码，默认 1"
+// @Param			pageSize	query		int								false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListGroupMembersResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/groups/{id}/members [get]
+func GetGroupMembers(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	members, meta, err := service.GroupSvc.ListMembers(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(members),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		添加群成员
+// @Description	添加群成员，需要 invite_members 权限，返回实际新增的成员 uuid
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"群 uuid"
+// @Param			request	body		dto.AddGroupMembersRequest		true	"请求参数"
+// @Success		201		{object}	common.SuccessResponse			"添加成功"
+// @Failure		400		{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/groups/{id}/members [post]
+func AddGroupMembers(
+	ctx *gin.Context,
+	req dto.AddGroupMembersRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := service.GroupSvc.AddMembers(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		added,
+	), nil
+}
+
+// @Summary		移除群成员
+// @Description	移除群成员，需要 remove_members 权限，并且只能移除角色等级更低的成员（owner > admin > moderator、自定义角色 > member）。移除操作会记录管理日志并在群内生成系统消息
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			userId	path		string						true	"成员 uuid"
+// @Param			reason	query		string						false	"原因，最多 200 个字符"
+// @Success		200		{object}	common.SuccessResponse		"移除成功"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/members/{userId} [delete]
+func RemoveGroupMember(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupSvc.RemoveMember(userId, ctx.Param("id"), ctx.Param("userId"), ctx.Query("reason")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		退出群聊
+// @Description	当前用户退出群聊，群主不能退出
+// @Tags			groups
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"群 uuid"
+// @Success		200	{object}	common.SuccessResponse		"退出成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/members/me [delete]
+func LeaveGroup(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupSvc.LeaveGroup(userId, ctx.Param("id")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		发送群聊消息
+// @Description	向群聊发送消息，需要 send_message 权限，图片、文件消息还需要 send_media 权限
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			request	body		dto.SendMessageRequest		true	"请求参数"
+// @Success		201		{object}	dto.SendMessageResponse		"发送成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/messages [post]
+func SendGroupMessage(
+	ctx *gin.Context,
+	req dto.SendMessageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := service.MessageSvc.SendGroupMessage(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		message,
+	), nil
+}
+
+// @Summary		获取群聊消息
+// @Description	基于游标分页获取群聊消息，按时间正序返回
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			before	query		string						false	"向前翻页的游标"
+// @Param			after	query		string						false	"向后翻页的游标"
+// @Param			limit	query		int							false	"条数，默认 20，最大 100"
+// @Success		200		{object}	dto.ListMessagesResponse	"获取成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/messages [get]
+func GetGroupMessages(
+	ctx *gin.Context,
+	req dto.ListMessagesRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages, meta, err := service.MessageSvc.ListGroupMessages(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(messages),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		获取置顶消息
+// @Description	获取群内置顶的消息，按置顶时间倒序返回，仅群成员可查看
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string							true	"群 uuid"
+// @Success		200	{object}	dto.PinnedMessagesResponse		"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/groups/{id}/pins [get]
+func GetGroupPins(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := service.MessageSvc.ListGroupPins(userId, ctx.Param("id"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		messages,
+	), nil
+}
+
+// @Summary		置顶消息
+// @Description	置顶群消息，需要 pin_message 权限，已置顶时不做任何修改
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string						true	"群 uuid"
+// @Param			messageId	path		string						true	"消息 uuid"
+// @Success		200			{object}	common.SuccessResponse		"置顶成功"
+// @Failure		401			{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/pins/{messageId} [put]
+func PinGroupMessage(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	return pinGroupMessage(ctx, true)
+}
+
+// @Summary		取消置顶消息
+// @Description	取消置顶群消息，需要 pin_message 权限
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string						true	"群 uuid"
+// @Param			messageId	path		string						true	"消息 uuid"
+// @Success		200			{object}	common.SuccessResponse		"取消成功"
+// @Failure		401			{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/pins/{messageId} [delete]
+func UnpinGroupMessage(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	return pinGroupMessage(ctx, false)
+}
+
+func pinGroupMessage(ctx *gin.Context, pinned bool) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.MessageSvc.PinGroupMessage(userId, ctx.Param("id"), ctx.Param("messageId"), pinned); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
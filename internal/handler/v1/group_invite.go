@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		创建邀请链接
+// @Description	创建群邀请链接，可以设置过期时间、最大使用次数以及是否需要审批，需要 invite_members 权限
+// @Tags			invites
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"群 uuid"
+// @Param			request	body		dto.CreateGroupInviteRequest	true	"请求参数"
+// @Success		201		{object}	dto.GroupInviteResponse			"创建成功"
+// @Failure		400		{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/groups/{id}/invites [post]
+func CreateGroupInvite(
+	ctx *gin.Context,
+	req dto.CreateGroupInviteRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	invite, err := service.GroupInviteSvc.CreateInvite(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		invite,
+	), nil
+}
+
+// @Summary		获取邀请链接
+// @Description	分页获取群内仍然可用的邀请链接，需要 invite_members 权限
+// @Tags			invites
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string							true	"群 uuid"
+// @Param			page		query		int								false	"页码，默认 1"
+// @Param			pageSize	query		int								false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListGroupInvitesResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/groups/{id}/invites [get]
+func GetGroupInvites(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	invites, meta, err := service.GroupInviteSvc.ListInvites(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(invites),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		撤销邀请链接
+// @Description	撤销邀请链接，撤销后链接立即失效，需要 invite_members 权限
+// @Tags			invites
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string						true	"群 uuid"
+// @Param			inviteId	path		string						true	"邀请链接 uuid"
+// @Success		200			{object}	common.SuccessResponse		"撤销成功"
+// @Failure		401			{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/invites/{inviteId} [delete]
+func RevokeGroupInvite(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupInviteSvc.RevokeInvite(userId, ctx.Param("id"), ctx.Param("inviteId")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		预览邀请链接
+// @Description	公开访问，加入前查看邀请链接对应的群名称、头像和成员数
+// @Tags			invites
+// @Accept			json
+// @Produce		json
+// @Param			token	path		string							true	"邀请 token"
+// @Success		200		{object}	dto.GroupInvitePreviewResponse	"获取成功"
+// @Router			/invites/{token} [get]
+func PreviewGroupInvite(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	preview, err := service.GroupInviteSvc.Preview(ctx.Param("token"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		preview,
+	), nil
+}
+
+// @Summary		通过邀请链接入群
+// @Description	通过邀请链接加入群聊，链接需要审批时创建入群申请并返回 pending。使用次数原子递增，并发加入不会超过上限
+// @Tags			invites
+// @Accept			json
+// @Produce		json
+// @Param			token	path		string						true	"邀请 token"
+// @Success		200		{object}	dto.JoinGroupResponse		"加入成功或已提交申请"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/invites/{token}/join [post]
+func JoinGroupByInvite(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := service.GroupInviteSvc.Join(userId, ctx.Param("token"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		result,
+	), nil
+}
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		申请加入私有群
+// @Description	申请加入私有群，可以附带附言，申请会实时推送给拥有 invite_members 权限的成员。同一用户只能有一条待审批的申请，被拒绝后需要等待冷却时间才能再次申请
+// @Tags			join-requests
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string								true	"群 uuid"
+// @Param			request	body		dto.CreateGroupJoinRequestRequest	true	"请求参数"
+// @Success		201		{object}	dto.GroupJoinRequestResponse		"申请成功"
+// @Failure		400		{object}	common.BadRequestResponse			"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse			"鉴权失败"
+// @Router			/groups/{id}/join-requests [post]
+func CreateGroupJoinRequest(
+	ctx *gin.Context,
+	req dto.CreateGroupJoinRequestRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := service.GroupJoinRequestSvc.CreateRequest(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		request,
+	), nil
+}
+
+// @Summary		获取我的入群申请
+// @Description	分页获取当前用户发起的入群申请及其审批状态
+// @Tags			join-requests
+// @Accept			json
+// @Produce		json
+// @Param			page		query		int									false	"页码，默认 1"
+// @Param			pageSize	query		int									false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListGroupJoinRequestsResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse			"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse			"鉴权失败"
+// @Router			/users/me/join-requests [get]
+func GetMyGroupJoinRequests(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests, meta, err := service.GroupJoinRequestSvc.ListMine(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(requests),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		获取入群申请
+// @Description	按申请时间分页获取群内待审批的入群申请，需要 invite_members 权限
+// @Tags			join-requests
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string								true	"群 uuid"
+// @Param			page		query		int									false	"页码，默认 1"
+// @Param			pageSize	query		int									false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListGroupJoinRequestsResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse			"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse			"鉴权失败"
+// @Router			/groups/{id}/join-requests [get]
+func GetGroupJoinRequests(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests, meta, err := service.GroupJoinRequestSvc.ListPending(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(requests),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		通过入群申请
+// @Description	通过入群申请，申请人加入群聊，需要 invite_members 权限
+// @Tags			join-requests
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string							true	"群 uuid"
+// @Param			requestId	path		string							true	"申请 uuid"
+// @Success		200			{object}	dto.GroupJoinRequestResponse	"操作成功"
+// @Failure		401			{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/groups/{id}/join-requests/{requestId}/approve [post]
+func ApproveGroupJoinRequest(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := service.GroupJoinRequestSvc.Approve(userId, ctx.Param("id"), ctx.Param("requestId"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		request,
+	), nil
+}
+
+// @Summary		拒绝入群申请
+// @Description	拒绝入群申请，被拒绝的用户在冷却时间内不能再次申请，需要 invite_members 权限
+// @Tags			join-requests
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string							true	"群 uuid"
+// @Param			requestId	path		string							true	"申请 uuid"
+// @Success		200			{object}	dto.GroupJoinRequestResponse	"操作成功"
+// @Failure		401			{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/groups/{id}/join-requests/{requestId}/reject [post]
+func RejectGroupJoinRequest(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := service.GroupJoinRequestSvc.Reject(userId, ctx.Param("id"), ctx.Param("requestId"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		request,
+	), nil
+}
+
+// @Summary		批量审批入群申请
+// @Description	批量通过或拒绝入群申请，返回实际处理的申请，不属于该群或已处理的申请会被忽略，需要 invite_members 权限
+// @Tags			join-requests
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string									true	"群 uuid"
+// @Param			request	body		dto.ReviewGroupJoinRequestsRequest		true	"请求参数"
+// @Success		200		{object}	dto.ListGroupJoinRequestsBatchResponse	"操作成功"
+// @Failure		400		{object}	common.BadRequestResponse				"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse				"鉴权失败"
+// @Router			/groups/{id}/join-requests/review [post]
+func ReviewGroupJoinRequests(
+	ctx *gin.Context,
+	req dto.ReviewGroupJoinRequestsRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := service.GroupJoinRequestSvc.Review(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		requests,
+	), nil
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		禁止用户加入
+// @Description	禁止用户加入群聊，用户在群内时会被移出，待审批的入群申请会被拒绝，之后不能通过邀请链接或入群申请重新加入。需要 ban_members 权限，并且只能禁止角色等级更低的成员
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			request	body		dto.BanGroupMemberRequest	true	"请求参数"
+// @Success		201		{object}	dto.GroupBanResponse		"操作成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/bans [post]
+func BanGroupMember(
+	ctx *gin.Context,
+	req dto.BanGroupMemberRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ban, err := service.GroupModerationSvc.Ban(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		ban,
+	), nil
+}
+
+// @Summary		获取禁止名单
+// @Description	分页获取群内被禁止加入的用户，最近禁止的在前，需要 ban_members 权限
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string						true	"群 uuid"
+// @Param			page		query		int							false	"页码，默认 1"
+// @Param			pageSize	query		int							false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListGroupBansResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/bans [get]
+func GetGroupBans(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bans, meta, err := service.GroupModerationSvc.ListBans(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(bans),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		解除禁止
+// @Description	解除禁止后用户可以重新加入群聊，需要 ban_members 权限
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			userId	path		string						true	"用户 uuid"
+// @Success		200		{object}	common.SuccessResponse		"操作成功"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/bans/{userId} [delete]
+func UnbanGroupMember(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupModerationSvc.Unban(userId, ctx.Param("id"), ctx.Param("userId")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		禁言成员
+// @Description	禁言成员一段时间，到期后自动解除，重复禁言会覆盖之前的禁言结束时间。需要 mute_members 权限，并且只能禁言角色等级更低的成员
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			userId	path		string						true	"成员 uuid"
+// @Param			request	body		dto.MuteGroupMemberRequest	true	"请求参数"
+// @Success		200		{object}	common.SuccessResponse		"操作成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/members/{userId}/mute [put]
+func MuteGroupMember(
+	ctx *gin.Context,
+	req dto.MuteGroupMemberRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupModerationSvc.Mute(userId, ctx.Param("id"), ctx.Param("userId"), req); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		解除禁言
+// @Description	提前解除成员的禁言，需要 mute_members 权限
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			userId	path		string						true	"成员 uuid"
+// @Success		200		{object}	common.SuccessResponse		"操作成功"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/members/{userId}/mute [delete]
+func UnmuteGroupMember(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupModerationSvc.Unmute(userId, ctx.Param("id"), ctx.Param("userId")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		获取管理日志
+// @Description	分页获取群管理日志（移出、禁止、禁言），最近的在前，需要 remove_members、ban_members 或 mute_members 中的任一权限
+// @Tags			moderation
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string								true	"群 uuid"
+// @Param			page		query		int									false	"页码，默认 1"
+// @Param			pageSize	query		int									false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.ListGroupModerationLogsResponse	"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse			"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse			"鉴权失败"
+// @Router			/groups/{id}/moderation-logs [get]
+func GetGroupModerationLogs(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs, meta, err := service.GroupModerationSvc.ListLogs(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(logs),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		获取群角色
+// @Description	获取群内的内置角色（owner、admin、moderator、member）和自定义角色及其权限，仅群成员可查看
+// @Tags			roles
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"群 uuid"
+// @Success		200	{object}	dto.ListGroupRolesResponse	"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/roles [get]
+func GetGroupRoles(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := service.GroupRoleSvc.ListRoles(userId, ctx.Param("id"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		roles,
+	), nil
+}
+
+// @Summary		创建自定义角色
+// @Description	创建自定义角色，名称不能与内置角色或已有角色重复，仅群主可操作
+// @Tags			roles
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			request	body		dto.CreateGroupRoleRequest	true	"请求参数"
+// @Success		201		{object}	dto.GroupRoleResponse		"创建成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/roles [post]
+func CreateGroupRole(
+	ctx *gin.Context,
+	req dto.CreateGroupRoleRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := service.GroupRoleSvc.CreateRole(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		role,
+	), nil
+}
+
+// @Summary		修改自定义角色
+// @Description	整体替换自定义角色的权限，内置角色不能修改，仅群主可操作
+// @Tags			roles
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			role	path		string						true	"角色名称"
+// @Param			request	body		dto.ModifyGroupRoleRequest	true	"请求参数"
+// @Success		200		{object}	dto.GroupRoleResponse		"修改成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/roles/{role} [patch]
+func ModifyGroupRole(
+	ctx *gin.Context,
+	req dto.ModifyGroupRoleRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := service.GroupRoleSvc.ModifyRole(userId, ctx.Param("id"), ctx.Param("role"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		role,
+	), nil
+}
+
+// @Summary		删除自定义角色
+// @Description	删除自定义角色，拥有该角色的成员恢复为普通成员，仅群主可操作
+// @Tags			roles
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			role	path		string						true	"角色名称"
+// @Success		200		{object}	common.SuccessResponse		"删除成功"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/roles/{role} [delete]
+func DeleteGroupRole(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupRoleSvc.DeleteRole(userId, ctx.Param("id"), ctx.Param("role")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		修改成员角色
+// @Description	将成员设置为除 owner 以外的内置角色或自定义角色，不能修改群主的角色，仅群主可操作
+// @Tags			roles
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"群 uuid"
+// @Param			userId	path		string						true	"成员 uuid"
+// @Param			request	body		dto.AssignGroupRoleRequest	true	"请求参数"
+// @Success		200		{object}	common.SuccessResponse		"修改成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/groups/{id}/members/{userId}/role [put]
+func AssignGroupMemberRole(
+	ctx *gin.Context,
+	req dto.AssignGroupRoleRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.GroupRoleSvc.AssignRole(userId, ctx.Param("id"), ctx.Param("userId"), req); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
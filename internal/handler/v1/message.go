@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		发送单聊消息
+// @Description	向指定用户发送单聊消息，会话不存在时自动创建
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"接收者 uuid"
+// @Param			request	body		dto.SendMessageRequest		true	"请求参数"
+// @Success		201		{object}	dto.SendMessageResponse		"发送成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/{id}/messages [post]
+func SendDirectMessage(
+	ctx *gin.Context,
+	req dto.SendMessageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := service.MessageSvc.SendDirectMessage(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResCreated,
+		message,
+	), nil
+}
+
+// @Summary		获取单聊消息
+// @Description	基于游标分页获取当前用户与指定用户之间的单聊消息，按时间正序返回
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"对方 uuid"
+// @Param			before	query		string						false	"向前翻页的游标"
+// @Param			after	query		string						false	"向后翻页的游标"
+// @Param			limit	query		int							false	"条数，默认 20，最大 100"
+// @Success		200		{object}	dto.ListMessagesResponse	"获取成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/{id}/messages [get]
+func GetDirectMessages(
+	ctx *gin.Context,
+	req dto.ListMessagesRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages, meta, err := service.MessageSvc.ListDirectMessages(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(messages),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// @Summary		编辑消息
+// @Description	编辑自己发送的文本消息，仅在发送后的可编辑时间内允许。编辑前的内容会保存为历史版本，并实时推送 message.edited 事件给会话参与者
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"消息 uuid"
+// @Param			request	body		dto.EditMessageRequest		true	"请求参数"
+// @Success		200		{object}	dto.EditMessageResponse		"编辑成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/messages/{id} [patch]
+func EditMessage(
+	ctx *gin.Context,
+	req dto.EditMessageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := service.MessageSvc.EditMessage(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		message,
+	), nil
+}
+
+// @Summary		删除消息
+// @Description	scope 为 me 时仅对自己删除；为 everyone 时撤回消息（仅发送者），消息在历史记录中变为墓碑，并实时推送 message.deleted 事件
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"消息 uuid"
+// @Param			scope	query		string						true	"删除范围：me、everyone"
+// @Success		200		{object}	common.SuccessResponse		"删除成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/messages/{id} [delete]
+func DeleteMessage(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.MessageSvc.DeleteMessage(userId, ctx.Param("id"), ctx.Query("scope")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		获取消息编辑历史
+// @Description	获取消息每次编辑前的内容，按编辑时间正序排列，仅会话参与者可以查看
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string							true	"消息 uuid"
+// @Success		200	{object}	dto.MessageRevisionsResponse	"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/messages/{id}/revisions [get]
+func GetMessageRevisions(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := service.MessageSvc.ListRevisions(userId, ctx.Param("id"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		revisions,
+	), nil
+}
+
+// @Summary		获取话题回复
+// @Description	基于游标分页获取话题的回复，按时间正序返回。回复通过发送消息接口的 threadRootUuid 参数发送
+// @Tags			messages
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"话题根消息 uuid"
+// @Param			before	query		string						false	"向前翻页的游标"
+// @Param			after	query		string						false	"向后翻页的游标"
+// @Param			limit	query		int							false	"条数，默认 20，最大 100"
+// @Success		200		{object}	dto.ListMessagesResponse	"获取成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/messages/{id}/replies [get]
+func GetThreadReplies(
+	ctx *gin.Context,
+	req dto.ListMessagesRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages, meta, err := service.MessageSvc.ListThreadReplies(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(messages),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		添加表情回应
+// @Description	对可见的消息添加表情回应，同一表情重复添加时忽略，并实时推送 message.reaction 事件给会话参与者。群聊中每条消息的表情种类有上限
+// @Tags			reactions
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"消息 uuid"
+// @Param			request	body		dto.AddReactionRequest			true	"请求参数"
+// @Success		200		{object}	dto.ReactionChangedResponse		"添加成功"
+// @Failure		400		{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/messages/{id}/reactions [post]
+func AddReaction(
+	ctx *gin.Context,
+	req dto.AddReactionRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reaction, err := service.ReactionSvc.AddReaction(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		reaction,
+	), nil
+}
+
+// @Summary		移除表情回应
+// @Description	移除自己对消息的表情回应，表情需要进行 URL 编码
+// @Tags			reactions
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"消息 uuid"
+// @Param			emoji	path		string							true	"表情"
+// @Success		200		{object}	dto.ReactionChangedResponse		"移除成功"
+// @Failure		401		{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/messages/{id}/reactions/{emoji} [delete]
+func RemoveReaction(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reaction, err := service.ReactionSvc.RemoveReaction(userId, ctx.Param("id"), ctx.Param("emoji"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		reaction,
+	), nil
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		确认消息回执
+// @Description	确认会话中某条消息及之前的所有消息已送达或已读，并实时通知消息发送者。也可以通过 WebSocket 事件 receipt.ack 提交
+// @Tags			receipts
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string						true	"会话 uuid"
+// @Param			request	body		dto.AckReceiptRequest		true	"请求参数"
+// @Success		200		{object}	dto.AckReceiptResponse		"确认成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/conversations/{id}/receipts [post]
+func AckReceipt(
+	ctx *gin.Context,
+	req dto.AckReceiptRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := service.ReceiptSvc.Acknowledge(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		receipt,
+	), nil
+}
+
+// @Summary		获取消息回执
+// @Description	获取消息的送达人数、已读人数以及按已读时间分页的已读用户列表，仅消息发送者可以查看
+// @Tags			receipts
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string							true	"消息 uuid"
+// @Param			page		query		int								false	"页码，默认 1"
+// @Param			pageSize	query		int								false	"每页条数，默认 20，最大 100"
+// @Success		200			{object}	dto.MessageReceiptsResponse		"获取成功"
+// @Failure		400			{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401			{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/messages/{id}/receipts [get]
+func GetMessageReceipts(
+	ctx *gin.Context,
+	req dto.PageRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, meta, err := service.ReceiptSvc.GetMessageReceipts(userId, ctx.Param("id"), req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(receipts),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
+
+// wsAckReceipt 处理 WebSocket 事件 receipt.ack
+func wsAckReceipt(client *ws.Client, req dto.AckReceiptRequest) *common.ServiceError {
+	_, err := service.ReceiptSvc.Acknowledge(client.UserId, req.ConversationUuid, req)
+	return err
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		刷新登录
+// @Description	使用 Refresh Token 换取新的 Access Token，同时返回新的 Refresh Token，旧的 Refresh Token 立即失效。已使用过的 Refresh Token 被再次使用时，本次登录签发的所有 Refresh Token 都会被吊销，需要重新登录
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.RefreshSessionRequest	true	"请求参数"
+// @Success		200		{object}	dto.LoginResponse			"刷新成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"Refresh Token 无效"
+// @Router			/sessions/refresh [post]
+func RefreshSession(
+	ctx *gin.Context,
+	req dto.RefreshSessionRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	res, err := service.SessionSvc.Refresh(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		res,
+	), nil
+}
+
+// @Summary		退出登录
+// @Description	吊销当前登录会话以及当前使用的 Access Token，之后使用该会话 Token 的请求都会返回 401
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	common.SuccessResponse		"退出成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/sessions/current [delete]
+func Logout(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	claims, err := currentClaims(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.SessionSvc.Logout(claims); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		获取登录设备
+// @Description	获取当前用户所有有效的登录会话，最近使用的在前，current 标记当前请求使用的会话
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	dto.ListSessionsResponse	"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/sessions [get]
+func GetSessions(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	claims, err := currentClaims(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := service.SessionSvc.ListSessions(claims)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		sessions,
+	), nil
+}
+
+// @Summary		退出登录设备
+// @Description	吊销指定的登录会话，该会话签发的 Token 立即失效，该设备的 WebSocket 连接会被断开
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			id	path		string						true	"会话 uuid"
+// @Success		200	{object}	common.SuccessResponse		"操作成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/sessions/{id} [delete]
+func DeleteSession(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	claims, err := currentClaims(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.SessionSvc.RevokeSession(claims, ctx.Param("id")); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		退出其他设备
+// @Description	吊销除当前会话以外的所有登录会话，这些设备的 WebSocket 连接会被断开
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	common.SuccessResponse		"操作成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/sessions/others [delete]
+func DeleteOtherSessions(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	claims, err := currentClaims(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.SessionSvc.RevokeOtherSessions(claims); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
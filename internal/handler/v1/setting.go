@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		获取当前用户设置
+// @Description	获取当前用户的偏好与隐私设置
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	dto.UserSettingResponse		"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/me/settings [get]
+func GetUsersMeSettings(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := service.SettingSvc.GetSettings(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		setting,
+	), nil
+}
+
+// @Summary		修改当前用户设置
+// @Description	修改当前用户的偏好与隐私设置，未传入的字段保持不变
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.ModifyUserSettingRequest	true	"请求参数"
+// @Success		200		{object}	dto.UserSettingResponse			"修改成功"
+// @Failure		400		{object}	common.BadRequestResponse		"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse		"鉴权失败"
+// @Router			/users/me/settings [patch]
+func ModifyUsersMeSettings(
+	ctx *gin.Context,
+	req dto.ModifyUserSettingRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := service.SettingSvc.ModifySettings(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		setting,
+	), nil
+}
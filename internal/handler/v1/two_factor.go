@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// @Summary		获取两步验证状态
+// @Description	获取当前用户是否已开启两步验证，以及剩余可用的恢复码数量
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	dto.TwoFactorStatusResponse	"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/me/2fa [get]
+func GetUsersMeTwoFactor(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := service.TwoFactorSvc.Status(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		status,
+	), nil
+}
+
+// @Summary		获取两步验证密钥
+// @Description	生成新的 TOTP 密钥和配置地址，使用验证器 App 扫描后需要调用确认接口才会开启两步验证。重复调用会替换未确认的密钥
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	dto.TwoFactorEnrollResponse	"获取成功"
+// @Failure		401	{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/me/2fa [post]
+func EnrollTwoFactor(
+	ctx *gin.Context,
+	req common.EmptyRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := service.TwoFactorSvc.Enroll(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		res,
+	), nil
+}
+
+// @Summary		开启两步验证
+// @Description	使用验证器 App 生成的验证码确认密钥并开启两步验证，返回一次性的恢复码，恢复码只会返回这一次
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.ConfirmTwoFactorRequest	true	"请求参数"
+// @Success		200		{object}	dto.RecoveryCodesResponse	"开启成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/me/2fa/confirm [post]
+func ConfirmTwoFactor(
+	ctx *gin.Context,
+	req dto.ConfirmTwoFactorRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := service.TwoFactorSvc.Confirm(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		res,
+	), nil
+}
+
+// @Summary		关闭两步验证
+// @Description	关闭两步验证，需要同时提供当前密码和验证码（或恢复码），关闭后密钥和恢复码都会被删除
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.DisableTwoFactorRequest	true	"请求参数"
+// @Success		200		{object}	common.SuccessResponse		"关闭成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users/me/2fa [delete]
+func DisableTwoFactor(
+	ctx *gin.Context,
+	req dto.DisableTwoFactorRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.TwoFactorSvc.Disable(userId, req); err != nil {
+		return nil, err
+	}
+
+	return common.ResOk, nil
+}
+
+// @Summary		两步验证登录
+// @Description	开启两步验证的用户登录时只会得到 challengeToken，使用它和验证码（或恢复码）换取 Access Token 和 Refresh Token。challengeToken 有效期 5 分钟，最多校验 5 次；同一用户连续输错 5 次后锁定 15 分钟
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			request	body		dto.VerifyTwoFactorRequest	true	"请求参数"
+// @Success		200		{object}	dto.LoginResponse			"登录成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"凭证无效"
+// @Router			/sessions/2fa [post]
+func VerifyTwoFactor(
+	ctx *gin.Context,
+	req dto.VerifyTwoFactorRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	res, err := service.TwoFactorSvc.Verify(req, ctx.Request.UserAgent(), ctx.ClientIP())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return common.WrapSuccessResponse(
+		common.ResOk,
+		res,
+	), nil
+}
@@ -10,7 +10,7 @@ import (
 )
 
 // @Summary		注册用户
-// @Description	传入参数，注册用户
+// @Description	传入参数，注册用户，填写邮箱时会发送验证邮件
 // @Tags			users
 // @Accept			json
 // @Produce		json
@@ -44,7 +44,7 @@ func Register(
 }
 
 // @Summary		用户登录
-// @Description	传入参数，用户登录
+// @Description	传入参数，用户登录，返回短期有效的 Access Token 和用于刷新的 Refresh Token。开启两步验证时只返回 challengeToken，需要调用 POST /sessions/2fa 完成登录
 // @Tags			users
 // @Accept			json
 // @Produce		json
@@ -56,7 +56,7 @@ func Login(
 	ctx *gin.Context,
 	req dto.LoginRequest,
 ) (*common.SuccessResponse, *common.ServiceError) {
-	res, loginErr := service.UserSvc.Login(&req)
+	res, loginErr := service.UserSvc.Login(&req, ctx.Request.UserAgent(), ctx.ClientIP())
 
 	// 数据库操作失败
 	if loginErr != nil {
@@ -93,7 +93,7 @@ func GetUsersMe(
 
 	log.Logger.Info("获取当前用户信息", log.Any("传参", userId))
 
-	userInfo, err := service.UserSvc.GetUserInfo(userId)
+	userInfo, err := service.UserSvc.GetUserInfo(userId, userId)
 
 	if err != nil {
 		return nil, err
@@ -122,7 +122,7 @@ func GetUsers(
 
 	log.Logger.Info("获取用户信息", log.Any("传参", id))
 
-	userInfo, err := service.UserSvc.GetUserInfo(id)
+	userInfo, err := service.UserSvc.GetUserInfo(optionalUserId(ctx), id)
 
 	if err != nil {
 		return nil, err
@@ -135,7 +135,7 @@ func GetUsers(
 }
 
 // @Summary		修改当前用户信息
-// @Description	传入参数，修改当前信息
+// @Description	传入参数，修改当前信息，包括个人简介、自定义状态、时区和首选语言。自定义状态变更会通过 WebSocket 事件 user.status_changed 推送给联系人。修改邮箱后需要通过发送到新邮箱的验证邮件确认，确认前 email 保持不变
 // @Tags			users
 // @Accept			json
 // @Produce		json
@@ -148,8 +148,6 @@ func ModifyUsersMe(
 	ctx *gin.Context,
 	req dto.ModifyUserInfoRequest,
 ) (*common.SuccessResponse, *common.ServiceError) {
-	var params dto.ModifyUserInfoRequest
-
 	userIdValue, ok := ctx.Get("userId")
 
 	if !ok {
@@ -158,7 +156,7 @@ func ModifyUsersMe(
 
 	userId := userIdValue.(string)
 
-	userInfo, err := service.UserSvc.ModifyUserInfo(userId, params)
+	userInfo, err := service.UserSvc.ModifyUserInfo(userId, req)
 
 	if err != nil {
 		return nil, err
@@ -169,3 +167,40 @@ func ModifyUsersMe(
 		userInfo,
 	), nil
 }
+
+// @Summary		搜索用户
+// @Description	按用户名或昵称前缀搜索用户，基于游标分页，meta 中返回匹配总数、hasMore 以及 nextCursor。不会返回关闭了搜索的用户以及存在屏蔽关系的用户，头像按对方的隐私设置过滤，接口有频率限制
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			q		query		string						true	"搜索关键字"
+// @Param			cursor	query		string						false	"翻页游标"
+// @Param			limit	query		int							false	"条数，默认 20，最大 100"
+// @Success		200		{object}	dto.SearchUsersResponse		"搜索成功"
+// @Failure		400		{object}	common.BadRequestResponse	"参数错误"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/users [get]
+func SearchUsers(
+	ctx *gin.Context,
+	req dto.SearchUsersRequest,
+) (*common.SuccessResponse, *common.ServiceError) {
+	userId, err := currentUserId(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	users, meta, err := service.UserSvc.SearchUsers(userId, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	common.SuccessListResponse(
+		ctx,
+		common.WithSuccessListResponseData(users),
+		common.WithSuccessListResponseMeta(meta),
+	)
+
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/handler/wrapper"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+// @Summary		建立 WebSocket 连接
+// @Description	升级为 WebSocket 连接，用于接收实时事件。Token 可通过 Authorization 请求头、token 查询参数或 Sec-WebSocket-Protocol（access_token, <token>）传递
+// @Tags			ws
+// @Param			token	query		string						false	"JWT Token"
+// @Success		101		{object}	ws.Event					"切换协议"
+// @Failure		401		{object}	common.UnauthorizedResponse	"鉴权失败"
+// @Router			/ws [get]
+func ServeWS(ctx *gin.Context) {
+	claims, err := currentClaims(ctx)
+
+	if err != nil {
+		common.GenerateFailedResponse(ctx, err)
+		return
+	}
+
+	// 升级失败时 upgrader 已经向客户端写入了错误响应
+	if err := ws.ClientHub.Serve(ctx.Writer, ctx.Request, claims.UserId, claims.SessionId); err != nil {
+		log.Logger.Warn("WebSocket 升级失败", log.String("userId", claims.UserId), log.Any("err", err))
+	}
+}
+
+// RegisterWSHandlers 注册客户端通过 WebSocket 发送的事件
+func RegisterWSHandlers() {
+	ws.ClientHub.HandleFunc(ws.EventReceiptAck, wrapper.WrapWSHandler(wsAckReceipt))
+	ws.ClientHub.HandleFunc(ws.EventHeartbeat, wrapper.WrapWSHandler(service.PresenceSvc.Heartbeat))
+	ws.ClientHub.HandleFunc(ws.EventTypingStart, wrapper.WrapWSHandler(service.TypingSvc.Start))
+	ws.ClientHub.HandleFunc(ws.EventTypingStop, wrapper.WrapWSHandler(service.TypingSvc.Stop))
+
+	// 在线状态
+	ws.ClientHub.OnConnect(service.PresenceSvc.HandleConnect)
+	ws.ClientHub.OnDisconnect(service.PresenceSvc.HandleDisconnect)
+}
@@ -1,10 +1,14 @@
 package wrapper
 
 import (
+	"encoding/json"
+	"net/http"
 	"reflect"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/shy-robin/gochat/internal/ws"
 	"github.com/shy-robin/gochat/pkg/common"
 	"github.com/shy-robin/gochat/pkg/global/log"
 )
@@ -33,7 +37,13 @@ func WrapGinHandler[T any](
 			// ShouldBindJSON 可以自定义错误信息，而 BindJSON 会默认返回 400 状态码
 			// 1) 调用 ShouldBindJSON
 			// Gin 会自动尝试解析 JSON 到 req 结构体，并根据 `validate` 标签进行校验。
-			err := ctx.ShouldBindJSON(&req)
+			// 2) GET 请求没有请求体，参数从 query 中解析（使用 form 标签）
+			var err error
+			if ctx.Request.Method == http.MethodGet {
+				err = ctx.ShouldBindQuery(&req)
+			} else {
+				err = ctx.ShouldBindJSON(&req)
+			}
 			//          ╭─────────────────────────────────────────────────────────╮
 			//          │                    上报请求参数日志                     │
 			//          ╰─────────────────────────────────────────────────────────╯
@@ -44,6 +54,9 @@ func WrapGinHandler[T any](
 				// 调用后，指针对应的 reqLog 将会设置脱敏后的密码
 				setter.SetPassword()
 			}
+			if masker, ok := any(&reqLog).(common.SecretMasker); ok {
+				masker.MaskSecrets()
+			}
 			apiName := ctx.Request.Method + ctx.Request.URL.Path
 			log.Logger.Info(apiName, log.Any("传参", reqLog))
 
@@ -62,11 +75,41 @@ func WrapGinHandler[T any](
 			return
 		}
 
-		// 业务 Handler 成功处理请求并已在内部调用 c.JSON/c.String 响应。
+		// 业务 Handler 返回 nil 表示已在内部调用 c.JSON/c.String 响应（如 SuccessListResponse）。
+		if res == nil {
+			return
+		}
+
 		common.GenerateSuccessResponse(ctx, res)
 	}
 }
 
+// WrapWSHandler 是将自定义 WebSocket 事件处理函数转换为 ws.EventHandler 的包装器。
+// 与 WrapGinHandler 一致：解析并校验事件数据，处理失败时向当前连接推送 error 事件
+func WrapWSHandler[T any](
+	handler func(*ws.Client, T) *common.ServiceError,
+) ws.EventHandler {
+	return func(client *ws.Client, data json.RawMessage) {
+		var req T
+
+		if err := json.Unmarshal(data, &req); err != nil {
+			client.SendError(common.WrapServiceError(common.ErrInvalidEvent, err))
+			return
+		}
+
+		// 复用 Gin 的校验器，使 binding 标签和自定义校验规则同样生效
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			client.SendError(translateValidationErrors(err))
+			return
+		}
+
+		if serviceErr := handler(client, req); serviceErr != nil {
+			log.Logger.Error("WebSocket 事件处理失败", log.String("userId", client.UserId), log.Any("err", serviceErr))
+			client.SendError(serviceErr)
+		}
+	}
+}
+
 // translateValidationErrors 将 validator.ValidationErrors 转换为一个 ServiceError
 func translateValidationErrors(err error) *common.ServiceError {
 	// 检查错误是否是 validator.ValidationErrors 类型
@@ -78,9 +121,10 @@ func translateValidationErrors(err error) *common.ServiceError {
 		fieldName := firstError.Field() // 结构体中的字段名 (如 Username)
 		tag := firstError.Tag()         // 校验规则 (如 required, min, email)
 
+		// 未配置错误信息的字段，使用通用的参数错误，避免返回 nil 导致响应失败
 		outerMap, ok := common.ValidateErrorMessages[fieldName]
 		if !ok {
-			return nil
+			return common.WrapServiceError(common.ErrInvalidInput, err)
 		}
 
 		value, ok := outerMap[tag]
@@ -88,7 +132,7 @@ func translateValidationErrors(err error) *common.ServiceError {
 			return value
 		}
 
-		return nil
+		return common.WrapServiceError(common.ErrInvalidInput, err)
 	}
 
 	// 如果不是校验错误，而是其他绑定错误（如 JSON 格式错误），使用通用错误
@@ -0,0 +1,75 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/mail"
+	"time"
+
+	"github.com/shy-robin/gochat/config"
+)
+
+// 发送方式
+const (
+	DriverSmtp   = "smtp"
+	DriverOutbox = "outbox"
+)
+
+// Message 是一封纯文本邮件
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 发送邮件，具体实现由 mail.driver 配置决定
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New 按配置创建 Mailer，未配置发送方式时使用 outbox
+func New(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSmtp:
+		return NewSmtpMailer(cfg)
+	case DriverOutbox, "":
+		return NewOutboxMailer(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
+
+// build 生成 RFC 5322 格式的邮件内容，主题和正文使用 UTF-8 编码
+func build(from string, msg Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	// base64 正文每行不超过 76 个字符
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+
+	return buf.Bytes()
+}
+
+// parseFrom 校验发件人，返回信封中使用的邮箱地址
+func parseFrom(from string) (string, error) {
+	address, err := mail.ParseAddress(from)
+	if err != nil {
+		return "", fmt.Errorf("invalid mail from %q: %w", from, err)
+	}
+	return address.Address, nil
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+// 未配置 mail.outboxPath 时的默认目录
+const defaultOutboxPath = "logs/outbox"
+
+// OutboxMailer 不真正发送邮件，而是将邮件写入本地目录的 .eml 文件，并将正文记录到日志中，用于开发和测试
+type OutboxMailer struct {
+	dir  string
+	from string
+}
+
+func NewOutboxMailer(cfg config.MailConfig) (*OutboxMailer, error) {
+	dir := cfg.OutboxPath
+	if dir == "" {
+		dir = defaultOutboxPath
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create outbox dir failed: %w", err)
+	}
+
+	return &OutboxMailer{dir: dir, from: cfg.From}, nil
+}
+
+func (this *OutboxMailer) Send(msg Message) error {
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405"), uuid.NewString())
+	path := filepath.Join(this.dir, name)
+
+	if err := os.WriteFile(path, build(this.from, msg), 0o644); err != nil {
+		return err
+	}
+
+	log.Logger.Info("邮件已写入 outbox",
+		log.String("to", msg.To),
+		log.String("subject", msg.Subject),
+		log.String("body", msg.Body),
+		log.String("path", path),
+	)
+
+	return nil
+}
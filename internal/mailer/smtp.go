@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+
+	"github.com/shy-robin/gochat/config"
+)
+
+// SmtpMailer 通过 SMTP 服务器发送邮件
+// net/smtp 会在服务器支持时自动启用 STARTTLS，不支持 465 端口的隐式 TLS
+type SmtpMailer struct {
+	addr string
+	from string
+	// 信封中的发件人地址
+	sender string
+	auth   smtp.Auth
+}
+
+func NewSmtpMailer(cfg config.MailConfig) (*SmtpMailer, error) {
+	sender, err := parseFrom(cfg.From)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SmtpMailer{
+		addr:   fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		from:   cfg.From,
+		sender: sender,
+		auth:   auth,
+	}, nil
+}
+
+func (this *SmtpMailer) Send(msg Message) error {
+	return smtp.SendMail(this.addr, this.auth, this.sender, []string{msg.To}, build(this.from, msg))
+}
@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/internal/service"
+	"github.com/shy-robin/gochat/internal/ws"
 	"github.com/shy-robin/gochat/pkg/common"
 )
 
@@ -18,12 +20,11 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		}
 
 		// 2. 检查格式是否为 "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
+		tokenString, ok := parseBearerToken(authHeader)
+		if !ok {
 			common.GenerateFailedResponse(ctx, common.ErrInvalidAuthorizationHeader)
 			return
 		}
-		tokenString := parts[1]
 
 		// 3. 解析和验证 Token
 		claims, err := common.ValidateToken(tokenString)
@@ -34,9 +35,96 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// 5. 验证成功，将用户信息存入 Context
-		ctx.Set("userId", claims.UserId)
-		ctx.Set("username", claims.Username)
+		// 5. 检查 Token 是否已在退出登录时被吊销
+		if serviceErr := service.SessionSvc.CheckToken(claims); serviceErr != nil {
+			common.GenerateFailedResponse(ctx, serviceErr)
+			return
+		}
+
+		// 6. 验证成功，将用户信息存入 Context
+		setClaims(ctx, claims)
 		ctx.Next() // 放行，请求继续执行后续的 Handler
 	}
 }
+
+// OptionalJWTAuthMiddleware 用于公开接口：携带有效 Token 时写入用户信息，否则按未登录处理
+func OptionalJWTAuthMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if tokenString, ok := parseBearerToken(ctx.GetHeader("Authorization")); ok {
+			if claims, err := common.ValidateToken(tokenString); err == nil && service.SessionSvc.CheckToken(claims) == nil {
+				setClaims(ctx, claims)
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// WSAuthMiddleware 用于 WebSocket 握手请求的鉴权
+// 浏览器的 WebSocket API 无法设置自定义请求头，因此 Token 按以下顺序查找：
+// 1. Authorization 请求头："Bearer <token>"
+// 2. query 参数：?token=<token>
+// 3. Sec-WebSocket-Protocol 请求头："access_token, <token>"
+func WSAuthMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenString, serviceErr := extractWSToken(ctx)
+		if serviceErr != nil {
+			common.GenerateFailedResponse(ctx, serviceErr)
+			return
+		}
+
+		claims, err := common.ValidateToken(tokenString)
+		if err != nil {
+			common.GenerateFailedResponse(ctx, common.ErrInvalidToken)
+			return
+		}
+
+		if serviceErr := service.SessionSvc.CheckToken(claims); serviceErr != nil {
+			common.GenerateFailedResponse(ctx, serviceErr)
+			return
+		}
+
+		setClaims(ctx, claims)
+		ctx.Next()
+	}
+}
+
+func extractWSToken(ctx *gin.Context) (string, *common.ServiceError) {
+	if authHeader := ctx.GetHeader("Authorization"); authHeader != "" {
+		tokenString, ok := parseBearerToken(authHeader)
+		if !ok {
+			return "", common.ErrInvalidAuthorizationHeader
+		}
+		return tokenString, nil
+	}
+
+	if tokenString := ctx.Query("token"); tokenString != "" {
+		return tokenString, nil
+	}
+
+	// 子协议以逗号分隔，Token 紧跟在 access_token 之后
+	if protocolHeader := ctx.GetHeader("Sec-WebSocket-Protocol"); protocolHeader != "" {
+		protocols := strings.Split(protocolHeader, ",")
+		for i, protocol := range protocols {
+			if strings.TrimSpace(protocol) == ws.TokenProtocol && i+1 < len(protocols) {
+				return strings.TrimSpace(protocols[i+1]), nil
+			}
+		}
+	}
+
+	return "", common.ErrMissAuthorizationHeader
+}
+
+func parseBearerToken(authHeader string) (string, bool) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func setClaims(ctx *gin.Context, claims *common.Claims) {
+	ctx.Set("userId", claims.UserId)
+	ctx.Set("username", claims.Username)
+	ctx.Set("claims", claims)
+}
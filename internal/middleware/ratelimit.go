@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shy-robin/gochat/pkg/common"
+	"golang.org/x/time/rate"
+)
+
+// 超过该时间没有请求的限流器会被清理
+const limiterIdleTimeout = 10 * time.Minute
+
+// RateLimitMiddleware 限制接口每分钟的调用次数，已登录时按用户限流，否则按客户端 IP 限流
+// 需要放在鉴权中间件之后，才能获取到当前用户
+func RateLimitMiddleware(perMinute int) gin.HandlerFunc {
+	limiters := newKeyedLimiter(rate.Every(time.Minute/time.Duration(perMinute)), perMinute)
+
+	return func(ctx *gin.Context) {
+		key := ctx.ClientIP()
+		if userId, ok := ctx.Get("userId"); ok {
+			key = userId.(string)
+		}
+
+		if !limiters.allow(key) {
+			common.GenerateFailedResponse(ctx, common.ErrTooManyRequests)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// keyedLimiter 为每个 key 维护一个令牌桶，并定期清理空闲的令牌桶
+type keyedLimiter struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	entries   map[string]*limiterEntry
+	lastPrune time.Time
+}
+
+func newKeyedLimiter(limit rate.Limit, burst int) *keyedLimiter {
+	return &keyedLimiter{
+		limit:     limit,
+		burst:     burst,
+		entries:   make(map[string]*limiterEntry),
+		lastPrune: time.Now(),
+	}
+}
+
+func (this *keyedLimiter) allow(key string) bool {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	now := time.Now()
+
+	if now.Sub(this.lastPrune) > limiterIdleTimeout {
+		for entryKey, entry := range this.entries {
+			if now.Sub(entry.lastSeen) > limiterIdleTimeout {
+				delete(this.entries, entryKey)
+			}
+		}
+		this.lastPrune = now
+	}
+
+	entry, ok := this.entries[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(this.limit, this.burst)}
+		this.entries[key] = entry
+	}
+	entry.lastSeen = now
+
+	return entry.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,9 @@
+package model
+
+// UserBlock 是用户的屏蔽名单
+// NOTE: 与 GroupMember 相同，取消屏蔽时需要物理删除
+type UserBlock struct {
+	BaseModel
+	BlockerUuid string `json:"blockerUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_blocker_blocked;comment:'屏蔽者uuid'"`
+	BlockedUuid string `json:"blockedUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_blocker_blocked;index:idx_blocked;comment:'被屏蔽者uuid'"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 会话类型
+const (
+	ConversationTypeDirect int8 = 1 // 单聊
+	ConversationTypeGroup  int8 = 2 // 群聊
+)
+
+type Conversation struct {
+	BaseModel
+	Uuid string `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	Type int8   `json:"type" gorm:"not null;comment:'会话类型 1:单聊 2:群聊'"`
+	// 单聊会话的唯一标识，由双方 Uuid 按字典序拼接，保证两个用户之间只存在一个单聊会话
+	DirectKey     *string    `json:"-" gorm:"type:varchar(310);uniqueIndex:idx_direct_key;comment:'单聊唯一标识'"`
+	UserAUuid     string     `json:"userAUuid" gorm:"type:varchar(150);index:idx_user_a;comment:'单聊用户A'"`
+	UserBUuid     string     `json:"userBUuid" gorm:"type:varchar(150);index:idx_user_b;comment:'单聊用户B'"`
+	GroupUuid     string     `json:"groupUuid" gorm:"type:varchar(150);index:idx_group;comment:'群聊uuid'"`
+	LastMessageAt *time.Time `json:"lastMessageAt" gorm:"comment:'最后一条消息时间'"`
+}
+
+func (this *Conversation) BeforeCreate(tx *gorm.DB) (err error) {
+	if this.Uuid == "" {
+		this.Uuid = uuid.NewString()
+	}
+	return nil
+}
+
+// DirectConversationKey 生成两个用户之间单聊会话的唯一标识，与参数顺序无关
+func DirectConversationKey(userUuid string, peerUuid string) string {
+	if userUuid > peerUuid {
+		userUuid, peerUuid = peerUuid, userUuid
+	}
+	return userUuid + ":" + peerUuid
+}
+
+// HasParticipant 判断用户是否为单聊会话的参与者
+func (this *Conversation) HasParticipant(userUuid string) bool {
+	return this.UserAUuid == userUuid || this.UserBUuid == userUuid
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// EmailVerification 记录一次发送的验证邮件，验证链接中的 Token 通过 Uuid 关联到该记录
+// 每个 Token 只能使用一次，过期后记录会被清理
+type EmailVerification struct {
+	BaseModel
+	Uuid     string `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	UserUuid string `json:"userUuid" gorm:"type:varchar(150);not null;index:idx_user;comment:'用户uuid'"`
+	// 需要验证的邮箱，可能是当前邮箱，也可能是待验证的新邮箱
+	Email     string     `json:"email" gorm:"type:varchar(80);not null;comment:'邮箱'"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"not null;index:idx_expires_at;comment:'过期时间'"`
+	UsedAt    *time.Time `json:"usedAt" gorm:"comment:'使用时间'"`
+}
+
+// IsUsable 判断验证记录是否可以使用：未使用且未过期
+func (this *EmailVerification) IsUsable() bool {
+	return this.UsedAt == nil && this.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 好友申请状态
+const (
+	FriendRequestPending  = "pending"
+	FriendRequestAccepted = "accepted"
+	FriendRequestDeclined = "declined"
+	FriendRequestCanceled = "canceled"
+	FriendRequestExpired  = "expired"
+)
+
+// 与当前用户的好友关系
+const (
+	FriendshipSelf            = "self"
+	FriendshipFriends         = "friends"
+	FriendshipRequestSent     = "request_sent"
+	FriendshipRequestReceived = "request_received"
+	// 当前用户屏蔽了对方
+	FriendshipBlocked = "blocked"
+	FriendshipNone    = "none"
+)
+
+type FriendRequest struct {
+	BaseModel
+	Uuid          string     `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	RequesterUuid string     `json:"requesterUuid" gorm:"type:varchar(150);not null;index:idx_requester;comment:'申请人uuid'"`
+	AddresseeUuid string     `json:"addresseeUuid" gorm:"type:varchar(150);not null;index:idx_addressee;comment:'被申请人uuid'"`
+	Message       string     `json:"message" gorm:"type:varchar(200);comment:'附言'"`
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';comment:'状态 pending/accepted/declined/canceled/expired'"`
+	ExpiresAt     time.Time  `json:"expiresAt" gorm:"not null;comment:'过期时间'"`
+	RespondedAt   *time.Time `json:"respondedAt" gorm:"comment:'处理时间'"`
+}
+
+func (this *FriendRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	if this.Uuid == "" {
+		this.Uuid = uuid.NewString()
+	}
+	return nil
+}
+
+// IsPending 判断申请是否仍在等待处理，超过过期时间但还未被定时任务标记的申请视为已过期
+func (this *FriendRequest) IsPending() bool {
+	return this.Status == FriendRequestPending && time.Now().Before(this.ExpiresAt)
+}
+
+// Friendship 是好友关系，每对好友保存两条记录（双向），便于按用户分页查询
+// NOTE: 与 GroupMember 相同，删除时需要物理删除
+type Friendship struct {
+	BaseModel
+	UserUuid   string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_user_friend;comment:'用户uuid'"`
+	FriendUuid string `json:"friendUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_user_friend;comment:'好友uuid'"`
+}
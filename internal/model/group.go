@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 群成员的内置角色，群主还可以创建自定义角色
+const (
+	GroupRoleOwner     = "owner"
+	GroupRoleAdmin     = "admin"
+	GroupRoleModerator = "moderator"
+	GroupRoleMember    = "member"
+)
+
+type Group struct {
+	BaseModel
+	Uuid             string `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	Name             string `json:"name" gorm:"type:varchar(50);not null;comment:'群名称'"`
+	Avatar           string `json:"avatar" gorm:"type:varchar(150);comment:'群头像'"`
+	OwnerUuid        string `json:"ownerUuid" gorm:"type:varchar(150);not null;index:idx_owner;comment:'群主uuid'"`
+	ConversationUuid string `json:"conversationUuid" gorm:"type:varchar(150);not null;comment:'群聊会话uuid'"`
+	MemberCount      int    `json:"memberCount" gorm:"not null;default:0;comment:'成员数量'"`
+	// 私有群只能通过邀请链接或入群申请加入
+	Private bool `json:"private" gorm:"not null;default:false;comment:'是否为私有群'"`
+}
+
+func (this *Group) BeforeCreate(tx *gorm.DB) (err error) {
+	if this.Uuid == "" {
+		this.Uuid = uuid.NewString()
+	}
+	return nil
+}
+
+// GroupMember 是群与用户的关联表
+// NOTE: 成员退出时物理删除记录，否则软删除的记录会与唯一索引冲突，导致无法重新入群
+type GroupMember struct {
+	BaseModel
+	GroupUuid string `json:"groupUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_group_user;comment:'群uuid'"`
+	UserUuid  string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_group_user;index:idx_user;comment:'用户uuid'"`
+	Role      string `json:"role" gorm:"type:varchar(20);not null;default:'member';comment:'角色 owner/admin/moderator/member 或自定义角色名称'"`
+	// 禁言结束时间，为空表示未被禁言，过期后由定时任务清除
+	MutedUntil *time.Time `json:"mutedUntil" gorm:"index:idx_muted_until;comment:'禁言结束时间'"`
+}
+
+// IsMuted 判断成员当前是否处于禁言中
+func (this *GroupMember) IsMuted() bool {
+	return this.MutedUntil != nil && this.MutedUntil.After(time.Now())
+}
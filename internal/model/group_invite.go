@@ -0,0 +1,85 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 入群申请状态
+const (
+	GroupJoinRequestPending  = "pending"
+	GroupJoinRequestApproved = "approved"
+	GroupJoinRequestRejected = "rejected"
+)
+
+// GroupInvite 是群邀请链接，通过 token 分享
+type GroupInvite struct {
+	BaseModel
+	Uuid        string `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	Token       string `json:"token" gorm:"type:varchar(64);not null;uniqueIndex:idx_token;comment:'邀请token'"`
+	GroupUuid   string `json:"groupUuid" gorm:"type:varchar(150);not null;index:idx_group;comment:'群uuid'"`
+	CreatorUuid string `json:"creatorUuid" gorm:"type:varchar(150);not null;comment:'创建人uuid'"`
+	// 为空表示永不过期
+	ExpiresAt *time.Time `json:"expiresAt" gorm:"comment:'过期时间'"`
+	// 为 0 表示不限制使用次数
+	MaxUses  int `json:"maxUses" gorm:"not null;default:0;comment:'最大使用次数'"`
+	UseCount int `json:"useCount" gorm:"not null;default:0;comment:'已使用次数'"`
+	// 开启后通过链接入群需要管理员审批
+	RequireApproval bool       `json:"requireApproval" gorm:"not null;default:false;comment:'是否需要审批'"`
+	RevokedAt       *time.Time `json:"revokedAt" gorm:"comment:'撤销时间'"`
+}
+
+func (this *GroupInvite) BeforeCreate(tx *gorm.DB) (err error) {
+	if this.Uuid == "" {
+		this.Uuid = uuid.NewString()
+	}
+	if this.Token == "" {
+		this.Token, err = newInviteToken()
+	}
+	return err
+}
+
+// IsActive 判断邀请链接当前是否可用：未撤销、未过期且未达到使用次数上限
+func (this *GroupInvite) IsActive() bool {
+	if this.RevokedAt != nil {
+		return false
+	}
+	if this.ExpiresAt != nil && !this.ExpiresAt.After(time.Now()) {
+		return false
+	}
+	return this.MaxUses == 0 || this.UseCount < this.MaxUses
+}
+
+// newInviteToken 生成 128 位随机 token，使用 URL 安全的 base64 编码
+func newInviteToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// GroupJoinRequest 是入群申请，需要管理员审批
+type GroupJoinRequest struct {
+	BaseModel
+	Uuid      string `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	GroupUuid string `json:"groupUuid" gorm:"type:varchar(150);not null;index:idx_group_status;comment:'群uuid'"`
+	UserUuid  string `json:"userUuid" gorm:"type:varchar(150);not null;index:idx_user;comment:'申请人uuid'"`
+	// 通过邀请链接发起的申请记录链接 uuid
+	InviteUuid   string     `json:"inviteUuid" gorm:"type:varchar(150);comment:'邀请链接uuid'"`
+	Message      string     `json:"message" gorm:"type:varchar(200);comment:'附言'"`
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_group_status;comment:'状态 pending/approved/rejected'"`
+	ReviewerUuid string     `json:"reviewerUuid" gorm:"type:varchar(150);comment:'审批人uuid'"`
+	ReviewedAt   *time.Time `json:"reviewedAt" gorm:"comment:'审批时间'"`
+}
+
+func (this *GroupJoinRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	if this.Uuid == "" {
+		this.Uuid = uuid.NewString()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// 群管理操作
+const (
+	GroupModerationKick   = "kick"
+	GroupModerationBan    = "ban"
+	GroupModerationUnban  = "unban"
+	GroupModerationMute   = "mute"
+	GroupModerationUnmute = "unmute"
+	// 禁言自动到期，由服务端执行，没有操作人
+	GroupModerationMuteExpired = "mute_expired"
+)
+
+// GroupBan 记录被禁止加入群聊的用户，被禁止的用户不能通过邀请链接或入群申请重新加入
+// NOTE: 解除禁止时物理删除记录，否则软删除的记录会与唯一索引冲突
+type GroupBan struct {
+	BaseModel
+	GroupUuid    string `json:"groupUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_group_user;comment:'群uuid'"`
+	UserUuid     string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_group_user;comment:'被禁止的用户uuid'"`
+	OperatorUuid string `json:"operatorUuid" gorm:"type:varchar(150);not null;comment:'操作人uuid'"`
+	Reason       string `json:"reason" gorm:"type:varchar(200);not null;default:'';comment:'原因'"`
+}
+
+// GroupModerationLog 记录群管理操作，禁言自动到期记录为 mute_expired，此时操作人为空
+type GroupModerationLog struct {
+	BaseModel
+	GroupUuid    string `json:"groupUuid" gorm:"type:varchar(150);not null;index:idx_group;comment:'群uuid'"`
+	Action       string `json:"action" gorm:"type:varchar(20);not null;comment:'操作 kick/ban/unban/mute/unmute/mute_expired'"`
+	TargetUuid   string `json:"targetUuid" gorm:"type:varchar(150);not null;comment:'被操作的用户uuid'"`
+	OperatorUuid string `json:"operatorUuid" gorm:"type:varchar(150);not null;default:'';comment:'操作人uuid'"`
+	Reason       string `json:"reason" gorm:"type:varchar(200);not null;default:'';comment:'原因'"`
+	// 仅禁言操作有效
+	MutedUntil *time.Time `json:"mutedUntil" gorm:"comment:'禁言结束时间'"`
+}
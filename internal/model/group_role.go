@@ -0,0 +1,112 @@
+package model
+
+// GroupPermission 是群内权限的位集合
+type GroupPermission int64
+
+// 群内权限，每个权限占用一个二进制位
+const (
+	GroupPermSendMessage GroupPermission = 1 << iota
+	// 发送图片、文件等非文本消息
+	GroupPermSendMedia
+	GroupPermPinMessage
+	GroupPermInviteMembers
+	GroupPermRemoveMembers
+	GroupPermEditGroup
+	// 禁止用户加入群聊，被禁止的用户会被移出群聊
+	GroupPermBanMembers
+	GroupPermMuteMembers
+)
+
+// 群主拥有全部权限，并且只有群主可以管理角色
+const GroupPermAll = GroupPermSendMessage | GroupPermSendMedia | GroupPermPinMessage |
+	GroupPermInviteMembers | GroupPermRemoveMembers | GroupPermEditGroup |
+	GroupPermBanMembers | GroupPermMuteMembers
+
+// GroupPermissionNames 是权限在接口中使用的名称
+var GroupPermissionNames = map[GroupPermission]string{
+	GroupPermSendMessage:   "send_message",
+	GroupPermSendMedia:     "send_media",
+	GroupPermPinMessage:    "pin_message",
+	GroupPermInviteMembers: "invite_members",
+	GroupPermRemoveMembers: "remove_members",
+	GroupPermEditGroup:     "edit_group",
+	GroupPermBanMembers:    "ban_members",
+	GroupPermMuteMembers:   "mute_members",
+}
+
+// 内置角色的权限
+var builtinGroupRoles = map[string]GroupPermission{
+	GroupRoleOwner:     GroupPermAll,
+	GroupRoleAdmin:     GroupPermAll,
+	GroupRoleModerator: GroupPermSendMessage | GroupPermSendMedia | GroupPermPinMessage | GroupPermRemoveMembers | GroupPermMuteMembers,
+	GroupRoleMember:    GroupPermSendMessage | GroupPermSendMedia,
+}
+
+// 内置角色的等级，只能移除等级更低的成员，自定义角色与 moderator 同级
+var groupRoleRanks = map[string]int{
+	GroupRoleOwner:     4,
+	GroupRoleAdmin:     3,
+	GroupRoleModerator: 2,
+	GroupRoleMember:    1,
+}
+
+const customGroupRoleRank = 2
+
+// Has 判断是否拥有指定的全部权限
+func (this GroupPermission) Has(perm GroupPermission) bool {
+	return this&perm == perm
+}
+
+// Names 返回权限名称列表，按位从低到高排列
+func (this GroupPermission) Names() []string {
+	names := []string{}
+	for perm := GroupPermSendMessage; perm&GroupPermAll != 0; perm <<= 1 {
+		if this.Has(perm) {
+			names = append(names, GroupPermissionNames[perm])
+		}
+	}
+	return names
+}
+
+// ParseGroupPermissions 将权限名称列表转换为位集合，存在未知名称时返回 false
+func ParseGroupPermissions(names []string) (GroupPermission, bool) {
+	var perms GroupPermission
+
+	for _, name := range names {
+		found := false
+		for perm, permName := range GroupPermissionNames {
+			if permName == name {
+				perms |= perm
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+
+	return perms, true
+}
+
+// BuiltinGroupRolePermissions 返回内置角色的权限，不是内置角色时返回 false
+func BuiltinGroupRolePermissions(role string) (GroupPermission, bool) {
+	perms, ok := builtinGroupRoles[role]
+	return perms, ok
+}
+
+// GroupRoleRank 返回角色等级，非内置角色视为自定义角色
+func GroupRoleRank(role string) int {
+	if rank, ok := groupRoleRanks[role]; ok {
+		return rank
+	}
+	return customGroupRoleRank
+}
+
+// GroupRole 是群主创建的自定义角色，成员的 Role 字段保存角色名称
+type GroupRole struct {
+	BaseModel
+	GroupUuid   string          `json:"groupUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_group_name;comment:'群uuid'"`
+	Name        string          `json:"name" gorm:"type:varchar(20);not null;uniqueIndex:idx_group_name;comment:'角色名称'"`
+	Permissions GroupPermission `json:"permissions" gorm:"not null;default:0;comment:'权限位集合'"`
+}
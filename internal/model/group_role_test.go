@@ -0,0 +1,124 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupPermissionHas(t *testing.T) {
+	perms := GroupPermSendMessage | GroupPermMuteMembers
+
+	cases := []struct {
+		name string
+		perm GroupPermission
+		want bool
+	}{
+		{"single granted", GroupPermSendMessage, true},
+		{"all granted", GroupPermSendMessage | GroupPermMuteMembers, true},
+		{"single missing", GroupPermBanMembers, false},
+		{"partially granted", GroupPermSendMessage | GroupPermBanMembers, false},
+		{"none", 0, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := perms.Has(c.perm); got != c.want {
+				t.Errorf("Has(%d) = %v, want %v", c.perm, got, c.want)
+			}
+		})
+	}
+}
+
+func TestGroupPermissionNamesRoundTrip(t *testing.T) {
+	names := GroupPermAll.Names()
+	if len(names) != len(GroupPermissionNames) {
+		t.Fatalf("GroupPermAll.Names() returned %d names, want %d", len(names), len(GroupPermissionNames))
+	}
+
+	perms, ok := ParseGroupPermissions(names)
+	if !ok || perms != GroupPermAll {
+		t.Errorf("ParseGroupPermissions(%v) = %d, %v, want %d, true", names, perms, ok, GroupPermAll)
+	}
+
+	want := []string{"send_message", "mute_members"}
+	if got := (GroupPermSendMessage | GroupPermMuteMembers).Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	if got := GroupPermission(0).Names(); len(got) != 0 {
+		t.Errorf("GroupPermission(0).Names() = %v, want empty", got)
+	}
+}
+
+func TestParseGroupPermissions(t *testing.T) {
+	cases := []struct {
+		name  string
+		names []string
+		want  GroupPermission
+		ok    bool
+	}{
+		{"empty", []string{}, 0, true},
+		{"single", []string{"pin_message"}, GroupPermPinMessage, true},
+		{"duplicated", []string{"pin_message", "pin_message"}, GroupPermPinMessage, true},
+		{"multiple", []string{"ban_members", "edit_group"}, GroupPermBanMembers | GroupPermEditGroup, true},
+		{"unknown", []string{"pin_message", "manage_roles"}, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := ParseGroupPermissions(c.names)
+			if got != c.want || ok != c.ok {
+				t.Errorf("ParseGroupPermissions(%v) = %d, %v, want %d, %v", c.names, got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
+
+func TestBuiltinGroupRolePermissions(t *testing.T) {
+	cases := []struct {
+		role    string
+		has     GroupPermission
+		missing GroupPermission
+	}{
+		{GroupRoleOwner, GroupPermAll, 0},
+		{GroupRoleAdmin, GroupPermAll, 0},
+		{GroupRoleModerator, GroupPermRemoveMembers | GroupPermMuteMembers, GroupPermBanMembers | GroupPermEditGroup | GroupPermInviteMembers},
+		{GroupRoleMember, GroupPermSendMessage | GroupPermSendMedia, GroupPermPinMessage | GroupPermRemoveMembers},
+	}
+
+	for _, c := range cases {
+		t.Run(c.role, func(t *testing.T) {
+			perms, ok := BuiltinGroupRolePermissions(c.role)
+			if !ok {
+				t.Fatalf("BuiltinGroupRolePermissions(%q) ok = false", c.role)
+			}
+			if !perms.Has(c.has) {
+				t.Errorf("%s permissions %v, want to include %v", c.role, perms.Names(), c.has.Names())
+			}
+			if c.missing != 0 && perms&c.missing != 0 {
+				t.Errorf("%s permissions %v, want to exclude %v", c.role, perms.Names(), c.missing.Names())
+			}
+		})
+	}
+
+	if _, ok := BuiltinGroupRolePermissions("custom"); ok {
+		t.Error(`BuiltinGroupRolePermissions("custom") ok = true, want false`)
+	}
+}
+
+func TestGroupRoleRank(t *testing.T) {
+	order := []string{GroupRoleMember, GroupRoleModerator, GroupRoleAdmin, GroupRoleOwner}
+	for i := 1; i < len(order); i++ {
+		if GroupRoleRank(order[i-1]) >= GroupRoleRank(order[i]) {
+			t.Errorf("rank of %s should be lower than %s", order[i-1], order[i])
+		}
+	}
+
+	custom := GroupRoleRank("reviewer")
+	if custom != GroupRoleRank(GroupRoleModerator) {
+		t.Errorf("custom role rank = %d, want same as moderator (%d)", custom, GroupRoleRank(GroupRoleModerator))
+	}
+	if custom <= GroupRoleRank(GroupRoleMember) || custom >= GroupRoleRank(GroupRoleAdmin) {
+		t.Errorf("custom role rank = %d, want between member and admin", custom)
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// LoginChallenge 是开启两步验证的用户通过密码校验后获得的临时凭证
+// 使用验证码换取正式的 Token 后立即删除，验证次数用尽时同样会被删除
+type LoginChallenge struct {
+	BaseModel
+	// 只保存凭证的 sha256，明文只在登录时返回给客户端
+	TokenHash string `json:"-" gorm:"type:char(64);not null;uniqueIndex:idx_token_hash;comment:'凭证哈希'"`
+	UserUuid  string `json:"userUuid" gorm:"type:varchar(150);not null;comment:'用户uuid'"`
+	// 登录时传入的设备名称，验证通过后用于创建登录会话
+	DeviceName string    `json:"deviceName" gorm:"type:varchar(100);comment:'设备名称'"`
+	Attempts   int       `json:"attempts" gorm:"not null;default:0;comment:'验证次数'"`
+	ExpiresAt  time.Time `json:"expiresAt" gorm:"not null;index:idx_expires_at;comment:'过期时间'"`
+}
+
+// NewLoginChallenge 生成随机凭证，返回待保存的记录和凭证明文
+func NewLoginChallenge(userUuid string, deviceName string, ttl time.Duration) (*LoginChallenge, string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &LoginChallenge{
+		TokenHash:  HashLoginChallenge(token),
+		UserUuid:   userUuid,
+		DeviceName: deviceName,
+		ExpiresAt:  time.Now().Add(ttl),
+	}, token, nil
+}
+
+// HashLoginChallenge 计算凭证的哈希：hex(sha256(token))
+func HashLoginChallenge(token string) string {
+	return hashToken(token)
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 消息类型
+const (
+	MessageTypeText  = "text"
+	MessageTypeImage = "image"
+	MessageTypeFile  = "file"
+	// 系统消息，由服务端生成（如群管理操作），内容为 JSON，客户端不能发送
+	MessageTypeSystem = "system"
+)
+
+// 删除范围
+const (
+	// 仅对自己隐藏
+	MessageDeleteScopeMe = "me"
+	// 对所有人撤回，消息变为墓碑
+	MessageDeleteScopeEveryone = "everyone"
+)
+
+type Message struct {
+	BaseModel
+	Uuid             string `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	ConversationUuid string `json:"conversationUuid" gorm:"type:varchar(150);not null;index:idx_conversation;comment:'会话uuid'"`
+	SenderUuid       string `json:"senderUuid" gorm:"type:varchar(150);not null;index:idx_sender;comment:'发送者uuid'"`
+	Type             string `json:"type" gorm:"type:varchar(20);not null;default:'text';comment:'消息类型'"`
+	// 文本消息为文本内容，图片、文件消息为资源地址
+	Content string `json:"content" gorm:"type:text;comment:'消息内容'"`
+	// 最后一次编辑的时间，未编辑过为空
+	EditedAt *time.Time `json:"editedAt" gorm:"comment:'编辑时间'"`
+	// 引用回复的消息uuid，为空表示没有引用
+	ReplyToUuid string `json:"replyToUuid" gorm:"type:varchar(150);not null;default:'';comment:'引用的消息uuid'"`
+	// 所属话题的根消息uuid，为空表示消息在会话主时间线中
+	ThreadRootUuid string `json:"threadRootUuid" gorm:"type:varchar(150);not null;default:'';index:idx_thread_root;comment:'话题根消息uuid'"`
+	// 以下字段仅对话题根消息有效
+	ReplyCount  int        `json:"replyCount" gorm:"not null;default:0;comment:'话题回复数'"`
+	LastReplyAt *time.Time `json:"lastReplyAt" gorm:"comment:'话题最后回复时间'"`
+	// 置顶时间，为空表示未置顶，目前仅群聊支持置顶
+	PinnedAt     *time.Time `json:"pinnedAt" gorm:"comment:'置顶时间'"`
+	PinnedByUuid string     `json:"pinnedByUuid" gorm:"type:varchar(150);not null;default:'';comment:'置顶人uuid'"`
+}
+
+func (this *Message) BeforeCreate(tx *gorm.DB) (err error) {
+	if this.Uuid == "" {
+		this.Uuid = uuid.NewString()
+	}
+	return nil
+}
+
+// MessageRevision 保存消息每次编辑前的内容
+type MessageRevision struct {
+	BaseModel
+	MessageUuid string `json:"messageUuid" gorm:"type:varchar(150);not null;index:idx_message;comment:'消息uuid'"`
+	Content     string `json:"content" gorm:"type:text;comment:'编辑前的内容'"`
+}
+
+// MessageHidden 记录用户「仅对自己删除」的消息
+type MessageHidden struct {
+	BaseModel
+	MessageUuid string `json:"messageUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_message_user;comment:'消息uuid'"`
+	UserUuid    string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_message_user;comment:'用户uuid'"`
+}
+
+// ThreadParticipant 记录参与话题的用户（根消息发送者和所有回复者），新回复会通知给他们
+type ThreadParticipant struct {
+	BaseModel
+	ThreadRootUuid string `json:"threadRootUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_thread_user;comment:'话题根消息uuid'"`
+	UserUuid       string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_thread_user;comment:'用户uuid'"`
+}
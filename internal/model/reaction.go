@@ -0,0 +1,10 @@
+package model
+
+// MessageReaction 记录用户对消息的表情回应，同一用户对同一消息的同一表情只能回应一次
+// NOTE: 表情列使用 utf8mb4_bin，utf8mb4_unicode_ci 会将所有补充平面字符视为相等，导致不同表情在唯一索引上冲突
+type MessageReaction struct {
+	BaseModel
+	MessageUuid string `json:"messageUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_message_user_emoji;comment:'消息uuid'"`
+	UserUuid    string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_message_user_emoji;comment:'用户uuid'"`
+	Emoji       string `json:"emoji" gorm:"type:varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;not null;uniqueIndex:idx_message_user_emoji;comment:'表情'"`
+}
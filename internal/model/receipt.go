@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// 回执状态
+const (
+	ReceiptStatusSent      = "sent"
+	ReceiptStatusDelivered = "delivered"
+	ReceiptStatusRead      = "read"
+)
+
+// MessageReceipt 记录用户在会话中已送达、已读的消息位置（水位线）
+// 消息 ID 自增，因此 ID 小于等于水位线的消息都视为已送达/已读，
+// 这样每个用户在每个会话中只需要一条记录，不会随群成员数量和消息数量膨胀
+type MessageReceipt struct {
+	BaseModel
+	ConversationUuid   string     `json:"conversationUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_conversation_user;comment:'会话uuid'"`
+	UserUuid           string     `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_conversation_user;comment:'用户uuid'"`
+	DeliveredMessageId uint       `json:"deliveredMessageId" gorm:"not null;default:0;comment:'已送达的最后一条消息ID'"`
+	DeliveredAt        *time.Time `json:"deliveredAt" gorm:"comment:'送达时间'"`
+	ReadMessageId      uint       `json:"readMessageId" gorm:"not null;default:0;comment:'已读的最后一条消息ID'"`
+	ReadAt             *time.Time `json:"readAt" gorm:"comment:'已读时间'"`
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshToken 用于换取新的 Access Token，每次使用后都会轮换
+// 同一次登录签发的所有 Refresh Token 属于同一个 family，已使用的 token 被再次使用时整个 family 都会被吊销
+// family 即一次登录，FamilyUuid 与该次登录的 Session Uuid 相同
+type RefreshToken struct {
+	BaseModel
+	// 只保存 token 的 sha256，明文只在签发时返回给客户端
+	TokenHash  string    `json:"-" gorm:"type:char(64);not null;uniqueIndex:idx_token_hash;comment:'token哈希'"`
+	FamilyUuid string    `json:"familyUuid" gorm:"type:varchar(150);not null;index:idx_family;comment:'token族uuid'"`
+	UserUuid   string    `json:"userUuid" gorm:"type:varchar(150);not null;index:idx_user;comment:'用户uuid'"`
+	ExpiresAt  time.Time `json:"expiresAt" gorm:"not null;index:idx_expires_at;comment:'过期时间'"`
+	// 轮换时间，不为空表示已被使用
+	UsedAt    *time.Time `json:"usedAt" gorm:"comment:'使用时间'"`
+	RevokedAt *time.Time `json:"revokedAt" gorm:"comment:'吊销时间'"`
+}
+
+// NewRefreshToken 生成 256 位随机 token，返回待保存的记录和 token 明文
+func NewRefreshToken(userUuid string, familyUuid string, ttl time.Duration) (*RefreshToken, string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &RefreshToken{
+		TokenHash:  HashRefreshToken(token),
+		FamilyUuid: familyUuid,
+		UserUuid:   userUuid,
+		ExpiresAt:  time.Now().Add(ttl),
+	}, token, nil
+}
+
+// HashRefreshToken 计算 token 的哈希：hex(sha256(token))
+func HashRefreshToken(token string) string {
+	return hashToken(token)
+}
+
+// randomToken 生成 256 位随机 token，使用 base64url 编码
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsable 判断 token 是否可以使用：未使用、未吊销且未过期
+func (this *RefreshToken) IsUsable() bool {
+	return this.UsedAt == nil && this.RevokedAt == nil && this.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// RevokedToken 记录退出登录后被吊销的 Access Token，Token 过期后记录会被清理
+type RevokedToken struct {
+	BaseModel
+	Jti       string    `json:"jti" gorm:"type:varchar(64);not null;uniqueIndex:idx_jti;comment:'Token唯一标识'"`
+	ExpiresAt time.Time `json:"expiresAt" gorm:"not null;index:idx_expires_at;comment:'Token过期时间'"`
+}
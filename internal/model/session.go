@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Session 表示一次登录（一个设备），该次登录签发的 Access Token 和 Refresh Token 都绑定到 Session 的 Uuid
+// Refresh Token 的 FamilyUuid 与 Session 的 Uuid 相同
+type Session struct {
+	BaseModel
+	Uuid       string `json:"uuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_uuid;comment:'uuid'"`
+	UserUuid   string `json:"userUuid" gorm:"type:varchar(150);not null;index:idx_user;comment:'用户uuid'"`
+	DeviceName string `json:"deviceName" gorm:"type:varchar(100);comment:'设备名称'"`
+	UserAgent  string `json:"userAgent" gorm:"type:varchar(512);comment:'登录时的User-Agent'"`
+	Ip         string `json:"ip" gorm:"type:varchar(64);comment:'登录时的IP'"`
+	// 最近一次使用该会话的 Token 访问接口的时间
+	LastUsedAt time.Time `json:"lastUsedAt" gorm:"not null;comment:'最近使用时间'"`
+	// 与最新的 Refresh Token 同时过期，每次刷新都会延长
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"not null;index:idx_expires_at;comment:'过期时间'"`
+	RevokedAt *time.Time `json:"revokedAt" gorm:"comment:'吊销时间'"`
+}
+
+// IsActive 判断会话是否有效：未吊销且未过期
+func (this *Session) IsActive() bool {
+	return this.RevokedAt == nil && this.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,53 @@
+package model
+
+// 资料字段的可见范围
+const (
+	VisibilityEveryone = "everyone"
+	// 仅好友可见
+	VisibilityContacts = "contacts"
+	VisibilityNobody   = "nobody"
+)
+
+// UserSetting 保存用户的偏好与隐私设置
+// NOTE: 布尔字段的零值与默认值不同，更新时需要使用 map，否则 false 不会被写入
+type UserSetting struct {
+	BaseModel
+	UserUuid string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_user;comment:'用户uuid'"`
+	// 关闭后，其他人无法看到该用户的已读状态
+	ReadReceipts bool `json:"readReceipts" gorm:"not null;default:true;comment:'是否发送已读回执'"`
+	// 关闭后，其他人无法通过搜索找到该用户
+	Searchable bool `json:"searchable" gorm:"not null;default:true;comment:'是否允许被搜索'"`
+	// 关闭后，其他人无法通过通讯录中的邮箱发现该用户
+	Discoverable bool `json:"discoverable" gorm:"not null;default:true;comment:'是否允许通过邮箱被发现'"`
+	// 资料字段的可见范围：everyone、contacts、nobody，本人始终可见
+	EmailVisibility    string `json:"emailVisibility" gorm:"type:varchar(20);not null;default:'contacts';comment:'邮箱可见范围'"`
+	AvatarVisibility   string `json:"avatarVisibility" gorm:"type:varchar(20);not null;default:'everyone';comment:'头像可见范围'"`
+	LastSeenVisibility string `json:"lastSeenVisibility" gorm:"type:varchar(20);not null;default:'everyone';comment:'最后在线时间可见范围'"`
+	BioVisibility      string `json:"bioVisibility" gorm:"type:varchar(20);not null;default:'everyone';comment:'个人简介可见范围'"`
+}
+
+// DefaultUserSetting 返回用户尚未修改过设置时的默认值
+func DefaultUserSetting(userUuid string) *UserSetting {
+	return &UserSetting{
+		UserUuid:           userUuid,
+		ReadReceipts:       true,
+		Searchable:         true,
+		Discoverable:       true,
+		EmailVisibility:    VisibilityContacts,
+		AvatarVisibility:   VisibilityEveryone,
+		LastSeenVisibility: VisibilityEveryone,
+		BioVisibility:      VisibilityEveryone,
+	}
+}
+
+// IsVisible 判断可见范围为 visibility 的字段对查看者是否可见，isContact 表示查看者是否为好友
+func IsVisible(visibility string, isContact bool) bool {
+	switch visibility {
+	case VisibilityEveryone:
+		return true
+	case VisibilityContacts:
+		return isContact
+	default:
+		return false
+	}
+}
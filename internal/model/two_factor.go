@@ -0,0 +1,72 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+)
+
+const (
+	// 开启两步验证时生成的恢复码数量
+	RecoveryCodeCount = 10
+	// 恢复码的随机字节数，编码后为 16 个字符，展示时每 4 个字符用 - 分隔
+	recoveryCodeSize = 10
+)
+
+// UserTwoFactor 保存用户的 TOTP 密钥
+// 获取密钥后需要使用验证码确认才会开启，确认前重新获取会替换未确认的密钥
+type UserTwoFactor struct {
+	BaseModel
+	UserUuid string `json:"userUuid" gorm:"type:varchar(150);not null;uniqueIndex:idx_user;comment:'用户uuid'"`
+	// 校验验证码时需要密钥明文，因此不能只保存哈希
+	Secret string `json:"-" gorm:"type:varchar(64);not null;comment:'TOTP密钥'"`
+	// 为空表示尚未确认
+	EnabledAt *time.Time `json:"enabledAt" gorm:"comment:'开启时间'"`
+	// 最近一次使用的验证码所在的时间步，用于防止验证码重放
+	LastUsedStep int64 `json:"-" gorm:"not null;default:0;comment:'最近使用的时间步'"`
+	// 连续校验失败的次数，达到上限后锁定一段时间，校验成功或锁定时清零
+	FailedAttempts int        `json:"-" gorm:"not null;default:0;comment:'连续失败次数'"`
+	LockedUntil    *time.Time `json:"-" gorm:"comment:'锁定截止时间'"`
+}
+
+// IsEnabled 判断两步验证是否已开启
+func (this *UserTwoFactor) IsEnabled() bool {
+	return this.EnabledAt != nil
+}
+
+// RecoveryCode 是无法使用验证器 App 时的一次性恢复码
+type RecoveryCode struct {
+	BaseModel
+	UserUuid string `json:"userUuid" gorm:"type:varchar(150);not null;index:idx_user;comment:'用户uuid'"`
+	// 只保存恢复码的 sha256，明文只在开启两步验证时返回给客户端
+	CodeHash string     `json:"-" gorm:"type:char(64);not null;index:idx_code_hash;comment:'恢复码哈希'"`
+	UsedAt   *time.Time `json:"usedAt" gorm:"comment:'使用时间'"`
+}
+
+// NewRecoveryCodes 生成一组恢复码，返回待保存的记录和恢复码明文
+func NewRecoveryCodes(userUuid string) ([]RecoveryCode, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	records := make([]RecoveryCode, 0, RecoveryCodeCount)
+	codes := make([]string, 0, RecoveryCodeCount)
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		buf := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(encoding.EncodeToString(buf))
+		code := raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16]
+
+		records = append(records, RecoveryCode{UserUuid: userUuid, CodeHash: HashRecoveryCode(code)})
+		codes = append(codes, code)
+	}
+
+	return records, codes, nil
+}
+
+// HashRecoveryCode 计算恢复码的哈希，忽略大小写、空格和分隔符
+func HashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	return hashToken(normalized)
+}
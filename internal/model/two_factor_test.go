@@ -0,0 +1,61 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+)
+
+var recoveryCodePattern = regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	records, codes, err := NewRecoveryCodes("user-uuid")
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes() error = %v", err)
+	}
+
+	if len(records) != RecoveryCodeCount || len(codes) != RecoveryCodeCount {
+		t.Fatalf("NewRecoveryCodes() returned %d records and %d codes, want %d", len(records), len(codes), RecoveryCodeCount)
+	}
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		if !recoveryCodePattern.MatchString(code) {
+			t.Errorf("code %q does not match format xxxx-xxxx-xxxx-xxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("code %q is duplicated", code)
+		}
+		seen[code] = true
+
+		if records[i].UserUuid != "user-uuid" {
+			t.Errorf("records[%d].UserUuid = %q, want %q", i, records[i].UserUuid, "user-uuid")
+		}
+		if records[i].CodeHash != HashRecoveryCode(code) {
+			t.Errorf("records[%d].CodeHash does not match the hash of its code", i)
+		}
+		if records[i].CodeHash == code {
+			t.Errorf("records[%d].CodeHash stores the plain code", i)
+		}
+	}
+}
+
+func TestHashRecoveryCodeNormalizesInput(t *testing.T) {
+	want := HashRecoveryCode("abcd-efgh-ijkl-mnop")
+
+	cases := []string{
+		"abcdefghijklmnop",
+		"ABCD-EFGH-IJKL-MNOP",
+		"abcd efgh ijkl mnop",
+		" abcd-efgh-ijkl-mnop ",
+	}
+
+	for _, code := range cases {
+		if got := HashRecoveryCode(code); got != want {
+			t.Errorf("HashRecoveryCode(%q) = %s, want %s", code, got, want)
+		}
+	}
+
+	if HashRecoveryCode("abcd-efgh-ijkl-mnoq") == want {
+		t.Error("HashRecoveryCode() returned the same hash for different codes")
+	}
+}
@@ -1,6 +1,11 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -14,6 +19,23 @@ type User struct {
 	Nickname string `json:"nickname" gorm:"comment:'昵称'"`
 	Avatar   string `json:"avatar" gorm:"type:varchar(150);comment:'头像'"`
 	Email    string `json:"email" gorm:"type:varchar(80);column:email;comment:'邮箱'"`
+	// 邮箱是否已通过验证邮件确认
+	EmailVerified bool `json:"emailVerified" gorm:"not null;default:false;comment:'邮箱是否已验证'"`
+	// 修改后尚未验证的新邮箱，验证通过后才会替换 Email
+	PendingEmail string `json:"pendingEmail" gorm:"type:varchar(80);comment:'待验证的新邮箱'"`
+	// 加盐的邮箱哈希，用于通讯录匹配，不对外返回
+	EmailHash string `json:"-" gorm:"type:char(64);index;comment:'邮箱哈希'"`
+	Bio       string `json:"bio" gorm:"type:varchar(200);comment:'个人简介'"`
+	// 自定义状态，过期后由后台任务清空
+	StatusText      string     `json:"statusText" gorm:"type:varchar(100);comment:'状态文字'"`
+	StatusEmoji     string     `json:"statusEmoji" gorm:"type:varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;comment:'状态表情'"`
+	StatusExpiresAt *time.Time `json:"statusExpiresAt" gorm:"index;comment:'状态过期时间'"`
+	// IANA 时区，如 Asia/Shanghai
+	Timezone string `json:"timezone" gorm:"type:varchar(64);comment:'时区'"`
+	// BCP 47 语言标签，如 zh-CN
+	Locale string `json:"locale" gorm:"type:varchar(35);comment:'首选语言'"`
+	// 最后一次在线的时间，所有设备都断开连接时更新
+	LastSeenAt *time.Time `json:"lastSeenAt" gorm:"comment:'最后在线时间'"`
 }
 
 // BeforeCreate 是 GORM 的 Hook 函数。
@@ -39,6 +61,27 @@ func (this *User) BeforeCreate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+// HashEmail 计算用于联系人发现的邮箱哈希：hex(sha256(salt + 小写邮箱))，邮箱为空时返回空字符串
+func HashEmail(salt string, email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if email == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(salt + email))
+	return hex.EncodeToString(sum[:])
+}
+
+// HasStatus 判断用户当前是否设置了未过期的自定义状态
+func (this *User) HasStatus() bool {
+	if this.StatusText == "" && this.StatusEmoji == "" {
+		return false
+	}
+
+	return this.StatusExpiresAt == nil || this.StatusExpiresAt.After(time.Now())
+}
+
 // 辅助方法：验证密码
 func (this *User) CheckPassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(this.Password), []byte(password))
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BlockRepository struct {
+}
+
+var BlockRepo = &BlockRepository{}
+
+// Block 屏蔽用户，已屏蔽时忽略，返回是否新增
+// 屏蔽的同时解除双方的好友关系，并撤销双方之间待处理的好友申请
+func (this *BlockRepository) Block(blockerUuid string, blockedUuid string, at time.Time) (bool, error) {
+	db := db.GetDB()
+	blocked := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		block := &model.UserBlock{BlockerUuid: blockerUuid, BlockedUuid: blockedUuid}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(block)
+		if result.Error != nil {
+			return result.Error
+		}
+		blocked = result.RowsAffected > 0
+
+		result = tx.Unscoped().
+			Where(
+				"(user_uuid = ? AND friend_uuid = ?) OR (user_uuid = ? AND friend_uuid = ?)",
+				blockerUuid, blockedUuid, blockedUuid, blockerUuid,
+			).
+			Delete(&model.Friendship{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Model(&model.FriendRequest{}).
+			Where(
+				"(requester_uuid = ? AND addressee_uuid = ?) OR (requester_uuid = ? AND addressee_uuid = ?)",
+				blockerUuid, blockedUuid, blockedUuid, blockerUuid,
+			).
+			Where("status = ?", model.FriendRequestPending).
+			Updates(map[string]any{"status": model.FriendRequestCanceled, "responded_at": at}).Error
+	})
+
+	return blocked, err
+}
+
+// Unblock 取消屏蔽，未屏蔽时忽略，返回是否删除了记录
+func (this *BlockRepository) Unblock(blockerUuid string, blockedUuid string) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Unscoped().
+		Where("blocker_uuid = ? AND blocked_uuid = ?", blockerUuid, blockedUuid).
+		Delete(&model.UserBlock{})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// IsBlocked 判断 blockerUuid 是否屏蔽了 blockedUuid
+func (this *BlockRepository) IsBlocked(blockerUuid string, blockedUuid string) (bool, error) {
+	db := db.GetDB()
+	var count int64
+
+	result := db.Model(&model.UserBlock{}).
+		Where("blocker_uuid = ? AND blocked_uuid = ?", blockerUuid, blockedUuid).
+		Count(&count)
+
+	return count > 0, result.Error
+}
+
+// IsBlockedEither 判断两个用户之间是否存在任一方向的屏蔽
+func (this *BlockRepository) IsBlockedEither(userUuid string, otherUuid string) (bool, error) {
+	db := db.GetDB()
+	var count int64
+
+	result := db.Model(&model.UserBlock{}).
+		Where(
+			"(blocker_uuid = ? AND blocked_uuid = ?) OR (blocker_uuid = ? AND blocked_uuid = ?)",
+			userUuid, otherUuid, otherUuid, userUuid,
+		).
+		Count(&count)
+
+	return count > 0, result.Error
+}
+
+// FindRelatedUuids 查询与用户存在屏蔽关系（屏蔽或被屏蔽）的所有用户
+func (this *BlockRepository) FindRelatedUuids(userUuid string) ([]string, error) {
+	db := db.GetDB()
+	blockedUuids := []string{}
+	blockerUuids := []string{}
+
+	result := db.Model(&model.UserBlock{}).Where("blocker_uuid = ?", userUuid).Pluck("blocked_uuid", &blockedUuids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	result = db.Model(&model.UserBlock{}).Where("blocked_uuid = ?", userUuid).Pluck("blocker_uuid", &blockerUuids)
+
+	return append(blockedUuids, blockerUuids...), result.Error
+}
+
+// FindBlocked 分页查询用户屏蔽的人，按屏蔽时间倒序排列
+func (this *BlockRepository) FindBlocked(blockerUuid string, offset int, limit int) ([]model.UserBlock, int64, error) {
+	db := db.GetDB()
+	blocks := []model.UserBlock{}
+	var total int64
+
+	query := db.Model(&model.UserBlock{}).Where("blocker_uuid = ?", blockerUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&blocks)
+
+	return blocks, total, result.Error
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type ConversationRepository struct {
+}
+
+var ConversationRepo = &ConversationRepository{}
+
+func (this *ConversationRepository) FindByUuid(uuid string) (*model.Conversation, error) {
+	db := db.GetDB()
+	conversation := &model.Conversation{}
+
+	result := db.Where("uuid = ?", uuid).First(conversation)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return conversation, result.Error
+}
+
+// FindDirect 查找两个用户之间的单聊会话
+func (this *ConversationRepository) FindDirect(userUuid string, peerUuid string) (*model.Conversation, error) {
+	db := db.GetDB()
+	conversation := &model.Conversation{}
+
+	result := db.Where("direct_key = ?", model.DirectConversationKey(userUuid, peerUuid)).First(conversation)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return conversation, result.Error
+}
+
+// FindOrCreateDirect 查找两个用户之间的单聊会话，不存在则创建
+func (this *ConversationRepository) FindOrCreateDirect(userUuid string, peerUuid string) (*model.Conversation, error) {
+	db := db.GetDB()
+	key := model.DirectConversationKey(userUuid, peerUuid)
+	conversation := &model.Conversation{}
+
+	result := db.
+		Where("direct_key = ?", key).
+		Attrs(model.Conversation{
+			Type:      model.ConversationTypeDirect,
+			DirectKey: &key,
+			UserAUuid: userUuid,
+			UserBUuid: peerUuid,
+		}).
+		FirstOrCreate(conversation)
+
+	if result.Error != nil {
+		// 并发创建时唯一索引冲突，说明会话已被另一个请求创建，重新查询即可
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return this.FindDirect(userUuid, peerUuid)
+		}
+		return nil, result.Error
+	}
+
+	return conversation, nil
+}
+
+// FindDirectPeerUuids 查询与用户有单聊会话的所有用户
+func (this *ConversationRepository) FindDirectPeerUuids(userUuid string) ([]string, error) {
+	db := db.GetDB()
+	conversations := []model.Conversation{}
+
+	result := db.
+		Select("user_a_uuid", "user_b_uuid").
+		Where("type = ? AND (user_a_uuid = ? OR user_b_uuid = ?)", model.ConversationTypeDirect, userUuid, userUuid).
+		Find(&conversations)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	peerUuids := make([]string, 0, len(conversations))
+	for _, conversation := range conversations {
+		if conversation.UserAUuid == userUuid {
+			peerUuids = append(peerUuids, conversation.UserBUuid)
+		} else {
+			peerUuids = append(peerUuids, conversation.UserAUuid)
+		}
+	}
+
+	return peerUuids, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type EmailVerificationRepository struct {
+}
+
+var EmailVerificationRepo = &EmailVerificationRepository{}
+
+func (this *EmailVerificationRepository) Create(verification *model.EmailVerification) error {
+	db := db.GetDB()
+
+	return db.Create(verification).Error
+}
+
+func (this *EmailVerificationRepository) FindByUuid(uuid string) (*model.EmailVerification, error) {
+	db := db.GetDB()
+	verification := &model.EmailVerification{}
+
+	result := db.Where("uuid = ?", uuid).First(verification)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return verification, result.Error
+}
+
+// FindLatestByUser 查询用户最近一次发送的验证邮件，用于限制发送频率
+func (this *EmailVerificationRepository) FindLatestByUser(userUuid string) (*model.EmailVerification, error) {
+	db := db.GetDB()
+	verification := &model.EmailVerification{}
+
+	result := db.Where("user_uuid = ?", userUuid).Order("created_at DESC").First(verification)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return verification, result.Error
+}
+
+// Apply 将验证记录标记为已使用，并更新用户的邮箱信息
+// 通过 used_at 条件保证同一记录只能使用一次，已被使用时返回 false
+func (this *EmailVerificationRepository) Apply(
+	verification *model.EmailVerification,
+	userUpdates map[string]any,
+) (bool, error) {
+	db := db.GetDB()
+	applied := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.EmailVerification{}).
+			Where("id = ? AND used_at IS NULL", verification.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		applied = true
+
+		return tx.Model(&model.User{}).Where("uuid = ?", verification.UserUuid).Updates(userUpdates).Error
+	})
+
+	return applied, err
+}
+
+// DeleteExpired 删除已过期的验证记录（物理删除）
+func (this *EmailVerificationRepository) DeleteExpired(now time.Time) error {
+	db := db.GetDB()
+
+	return db.Unscoped().Where("expires_at <= ?", now).Delete(&model.EmailVerification{}).Error
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FriendRepository struct {
+}
+
+var FriendRepo = &FriendRepository{}
+
+func (this *FriendRepository) CreateRequest(request *model.FriendRequest) error {
+	db := db.GetDB()
+
+	return db.Create(request).Error
+}
+
+func (this *FriendRepository) FindRequestByUuid(uuid string) (*model.FriendRequest, error) {
+	db := db.GetDB()
+	request := &model.FriendRequest{}
+
+	result := db.Where("uuid = ?", uuid).First(request)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return request, result.Error
+}
+
+// FindPendingRequest 查询 requesterUuid 发给 addresseeUuid 且尚未过期的待处理申请
+func (this *FriendRepository) FindPendingRequest(requesterUuid string, addresseeUuid string) (*model.FriendRequest, error) {
+	db := db.GetDB()
+	requests := []model.FriendRequest{}
+
+	result := db.
+		Where("requester_uuid = ? AND addressee_uuid = ?", requesterUuid, addresseeUuid).
+		Where("status = ? AND expires_at > ?", model.FriendRequestPending, time.Now()).
+		Order("id DESC").
+		Limit(1).
+		Find(&requests)
+
+	if result.Error != nil || len(requests) == 0 {
+		return nil, result.Error
+	}
+
+	return &requests[0], nil
+}
+
+// FindPendingRequests 分页查询待处理的申请，incoming 为 true 时查询收到的申请，否则查询发出的申请
+func (this *FriendRepository) FindPendingRequests(
+	userUuid string,
+	incoming bool,
+	offset int,
+	limit int,
+) ([]model.FriendRequest, int64, error) {
+	db := db.GetDB()
+	requests := []model.FriendRequest{}
+	var total int64
+
+	column := "requester_uuid"
+	if incoming {
+		column = "addressee_uuid"
+	}
+
+	query := db.Model(&model.FriendRequest{}).
+		Where(column+" = ?", userUuid).
+		Where("status = ? AND expires_at > ?", model.FriendRequestPending, time.Now())
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&requests)
+
+	return requests, total, result.Error
+}
+
+// UpdateRequestStatus 将待处理的申请更新为指定状态，申请已不是待处理状态时返回 false
+// 通过条件更新保证并发处理同一申请时只有一次成功
+func (this *FriendRepository) UpdateRequestStatus(uuid string, status string, at time.Time) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.FriendRequest{}).
+		Where("uuid = ? AND status = ? AND expires_at > ?", uuid, model.FriendRequestPending, at).
+		Updates(map[string]any{"status": status, "responded_at": at})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// AcceptRequest 接受申请并建立双向好友关系，申请已不是待处理状态时返回 false
+func (this *FriendRepository) AcceptRequest(request *model.FriendRequest, at time.Time) (bool, error) {
+	db := db.GetDB()
+	accepted := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.FriendRequest{}).
+			Where("uuid = ? AND status = ? AND expires_at > ?", request.Uuid, model.FriendRequestPending, at).
+			Updates(map[string]any{"status": model.FriendRequestAccepted, "responded_at": at})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		accepted = true
+
+		friendships := []model.Friendship{
+			{UserUuid: request.RequesterUuid, FriendUuid: request.AddresseeUuid},
+			{UserUuid: request.AddresseeUuid, FriendUuid: request.RequesterUuid},
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&friendships).Error
+	})
+
+	return accepted, err
+}
+
+// ExpireRequests 将已超过过期时间的待处理申请标记为已过期
+func (this *FriendRepository) ExpireRequests(now time.Time) (int64, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.FriendRequest{}).
+		Where("status = ? AND expires_at <= ?", model.FriendRequestPending, now).
+		Update("status", model.FriendRequestExpired)
+
+	return result.RowsAffected, result.Error
+}
+
+func (this *FriendRepository) IsFriend(userUuid string, friendUuid string) (bool, error) {
+	db := db.GetDB()
+	var count int64
+
+	result := db.Model(&model.Friendship{}).
+		Where("user_uuid = ? AND friend_uuid = ?", userUuid, friendUuid).
+		Count(&count)
+
+	return count > 0, result.Error
+}
+
+// FindFriends 分页查询用户的好友，按成为好友的时间倒序排列
+func (this *FriendRepository) FindFriends(userUuid string, offset int, limit int) ([]model.Friendship, int64, error) {
+	db := db.GetDB()
+	friendships := []model.Friendship{}
+	var total int64
+
+	query := db.Model(&model.Friendship{}).Where("user_uuid = ?", userUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&friendships)
+
+	return friendships, total, result.Error
+}
+
+// FindFriendUuidsIn 查询 friendUuids 中哪些是用户的好友
+func (this *FriendRepository) FindFriendUuidsIn(userUuid string, friendUuids []string) ([]string, error) {
+	db := db.GetDB()
+	uuids := []string{}
+
+	if len(friendUuids) == 0 {
+		return uuids, nil
+	}
+
+	result := db.Model(&model.Friendship{}).
+		Where("user_uuid = ? AND friend_uuid IN ?", userUuid, friendUuids).
+		Pluck("friend_uuid", &uuids)
+
+	return uuids, result.Error
+}
+
+// FindFriendUuids 查询用户所有好友的 uuid，用于实时事件推送
+func (this *FriendRepository) FindFriendUuids(userUuid string) ([]string, error) {
+	db := db.GetDB()
+	uuids := []string{}
+
+	result := db.Model(&model.Friendship{}).
+		Where("user_uuid = ?", userUuid).
+		Pluck("friend_uuid", &uuids)
+
+	return uuids, result.Error
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GroupRepository struct {
+}
+
+var GroupRepo = &GroupRepository{}
+
+// CreateGroup 创建群聊及其会话，并写入初始成员
+func (this *GroupRepository) CreateGroup(group *model.Group, members []model.GroupMember) error {
+	db := db.GetDB()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		conversation := &model.Conversation{
+			Type: model.ConversationTypeGroup,
+		}
+		if err := tx.Create(conversation).Error; err != nil {
+			return err
+		}
+
+		group.ConversationUuid = conversation.Uuid
+		group.MemberCount = len(members)
+		if err := tx.Create(group).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(conversation).Update("group_uuid", group.Uuid).Error; err != nil {
+			return err
+		}
+
+		for i := range members {
+			members[i].GroupUuid = group.Uuid
+		}
+
+		return tx.Create(&members).Error
+	})
+}
+
+func (this *GroupRepository) FindByUuid(uuid string) (*model.Group, error) {
+	db := db.GetDB()
+	group := &model.Group{}
+
+	result := db.Where("uuid = ?", uuid).First(group)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return group, result.Error
+}
+
+func (this *GroupRepository) UpdatesByUuid(uuid string, updates map[string]any) (*model.Group, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.Group{}).Where("uuid = ?", uuid).Updates(updates)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return this.FindByUuid(uuid)
+}
+
+// FindByMember 分页查询用户加入的群
+func (this *GroupRepository) FindByMember(userUuid string, offset int, limit int) ([]model.Group, int64, error) {
+	db := db.GetDB()
+	groups := []model.Group{}
+	var total int64
+
+	query := db.Model(&model.Group{}).
+		Joins("JOIN group_members ON group_members.group_uuid = groups.uuid").
+		Where("group_members.user_uuid = ?", userUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("group_members.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&groups)
+
+	return groups, total, result.Error
+}
+
+func (this *GroupRepository) FindMember(groupUuid string, userUuid string) (*model.GroupMember, error) {
+	db := db.GetDB()
+	member := &model.GroupMember{}
+
+	result := db.Where("group_uuid = ? AND user_uuid = ?", groupUuid, userUuid).First(member)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return member, result.Error
+}
+
+// FindMembers 按入群顺序分页查询群成员
+func (this *GroupRepository) FindMembers(groupUuid string, offset int, limit int) ([]model.GroupMember, int64, error) {
+	db := db.GetDB()
+	members := []model.GroupMember{}
+	var total int64
+
+	query := db.Model(&model.GroupMember{}).Where("group_uuid = ?", groupUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&members)
+
+	return members, total, result.Error
+}
+
+// FindMemberUuids 查询群内所有成员的 uuid，用于实时事件推送
+func (this *GroupRepository) FindMemberUuids(groupUuid string) ([]string, error) {
+	db := db.GetDB()
+	uuids := []string{}
+
+	result := db.Model(&model.GroupMember{}).
+		Where("group_uuid = ?", groupUuid).
+		Pluck("user_uuid", &uuids)
+
+	return uuids, result.Error
+}
+
+// FindMemberUuidsByRoles 查询拥有指定角色的成员 uuid
+func (this *GroupRepository) FindMemberUuidsByRoles(groupUuid string, roles []string) ([]string, error) {
+	db := db.GetDB()
+	uuids := []string{}
+
+	if len(roles) == 0 {
+		return uuids, nil
+	}
+
+	result := db.Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND role IN ?", groupUuid, roles).
+		Pluck("user_uuid", &uuids)
+
+	return uuids, result.Error
+}
+
+// UpdateMemberRole 修改成员角色
+func (this *GroupRepository) UpdateMemberRole(groupUuid string, userUuid string, role string) error {
+	db := db.GetDB()
+
+	return db.Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND user_uuid = ?", groupUuid, userUuid).
+		Update("role", role).Error
+}
+
+// AddMembers 批量添加成员，已在群内的用户会被忽略，返回实际新增的成员 uuid
+func (this *GroupRepository) AddMembers(groupUuid string, userUuids []string) ([]string, error) {
+	db := db.GetDB()
+	added := []string{}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		existing := []string{}
+		if err := tx.Model(&model.GroupMember{}).
+			Where("group_uuid = ? AND user_uuid IN ?", groupUuid, userUuids).
+			Pluck("user_uuid", &existing).Error; err != nil {
+			return err
+		}
+
+		existingSet := make(map[string]struct{}, len(existing))
+		for _, userUuid := range existing {
+			existingSet[userUuid] = struct{}{}
+		}
+
+		for _, userUuid := range userUuids {
+			if _, ok := existingSet[userUuid]; ok {
+				continue
+			}
+			existingSet[userUuid] = struct{}{}
+
+			// 并发添加同一用户时，唯一索引保证不会重复入群，只有实际插入的成员才计入结果
+			inserted, err := addMember(tx, groupUuid, userUuid)
+			if err != nil {
+				return err
+			}
+
+			if inserted {
+				added = append(added, userUuid)
+			}
+		}
+
+		return nil
+	})
+
+	return added, err
+}
+
+// RemoveMember 移除群成员（物理删除），返回是否删除了记录
+func (this *GroupRepository) RemoveMember(groupUuid string, userUuid string) (bool, error) {
+	db := db.GetDB()
+	removed := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		removed, err = removeMember(tx, groupUuid, userUuid)
+		return err
+	})
+
+	return removed, err
+}
+
+// UpdateMemberMute 设置成员的禁言结束时间，为空表示解除禁言，成员不存在时返回 false
+func (this *GroupRepository) UpdateMemberMute(groupUuid string, userUuid string, mutedUntil *time.Time) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND user_uuid = ?", groupUuid, userUuid).
+		Update("muted_until", mutedUntil)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindExpiredMutes 查询禁言已到期的成员
+func (this *GroupRepository) FindExpiredMutes(now time.Time) ([]model.GroupMember, error) {
+	db := db.GetDB()
+	members := []model.GroupMember{}
+
+	result := db.Where("muted_until <= ?", now).Find(&members)
+
+	return members, result.Error
+}
+
+// ClearExpiredMute 清除成员已到期的禁言，查询之后重新禁言或已解除禁言时返回 false
+func (this *GroupRepository) ClearExpiredMute(member *model.GroupMember, now time.Time) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.GroupMember{}).
+		Where("id = ? AND muted_until <= ?", member.ID, now).
+		Update("muted_until", nil)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// removeMember 在事务中移除群成员（物理删除），不在群内时返回 false
+func removeMember(tx *gorm.DB, groupUuid string, userUuid string) (bool, error) {
+	result := tx.Unscoped().
+		Where("group_uuid = ? AND user_uuid = ?", groupUuid, userUuid).
+		Delete(&model.GroupMember{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+
+	err := tx.Model(&model.Group{}).
+		Where("uuid = ?", groupUuid).
+		Update("member_count", gorm.Expr("member_count - ?", result.RowsAffected)).Error
+
+	return err == nil, err
+}
+
+// addMember 在事务中将用户以普通成员身份加入群聊，已在群内时返回 false
+func addMember(tx *gorm.DB, groupUuid string, userUuid string) (bool, error) {
+	member := &model.GroupMember{
+		GroupUuid: groupUuid,
+		UserUuid:  userUuid,
+		Role:      model.GroupRoleMember,
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(member)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+
+	err := tx.Model(&model.Group{}).
+		Where("uuid = ?", groupUuid).
+		Update("member_count", gorm.Expr("member_count + 1")).Error
+
+	return err == nil, err
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type GroupInviteRepository struct {
+}
+
+var GroupInviteRepo = &GroupInviteRepository{}
+
+// errInviteUnavailable 表示邀请链接在事务中被判定为不可用，用于回滚事务
+var errInviteUnavailable = errors.New("invite unavailable")
+
+// errAlreadyMember 用于在用户已是群成员时回滚事务，撤销已消耗的使用次数
+var errAlreadyMember = errors.New("already a member")
+
+func (this *GroupInviteRepository) Create(invite *model.GroupInvite) error {
+	db := db.GetDB()
+
+	return db.Create(invite).Error
+}
+
+func (this *GroupInviteRepository) FindByUuid(uuid string) (*model.GroupInvite, error) {
+	db := db.GetDB()
+	invite := &model.GroupInvite{}
+
+	result := db.Where("uuid = ?", uuid).First(invite)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return invite, result.Error
+}
+
+func (this *GroupInviteRepository) FindByToken(token string) (*model.GroupInvite, error) {
+	db := db.GetDB()
+	invite := &model.GroupInvite{}
+
+	result := db.Where("token = ?", token).First(invite)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return invite, result.Error
+}
+
+// FindActive 分页查询群内仍然可用的邀请链接，按创建时间倒序
+func (this *GroupInviteRepository) FindActive(
+	groupUuid string,
+	now time.Time,
+	offset int,
+	limit int,
+) ([]model.GroupInvite, int64, error) {
+	db := db.GetDB()
+	invites := []model.GroupInvite{}
+	var total int64
+
+	query := activeInvites(db.Model(&model.GroupInvite{}), now).Where("group_uuid = ?", groupUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&invites)
+
+	return invites, total, result.Error
+}
+
+// Revoke 撤销邀请链接，已撤销时不做处理
+func (this *GroupInviteRepository) Revoke(uuid string, now time.Time) error {
+	db := db.GetDB()
+
+	return db.Model(&model.GroupInvite{}).
+		Where("uuid = ? AND revoked_at IS NULL", uuid).
+		Update("revoked_at", now).Error
+}
+
+// JoinByInvite 消耗一次邀请链接的使用次数并加入群聊
+// 返回链接是否可用，以及用户是否加入了群聊，用户已是群成员时回滚使用次数
+// 使用次数通过带条件的 UPDATE 原子递增，并发加入不会超过次数上限
+func (this *GroupInviteRepository) JoinByInvite(inviteUuid string, groupUuid string, userUuid string) (bool, bool, error) {
+	db := db.GetDB()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := useInvite(tx, inviteUuid); err != nil {
+			return err
+		}
+
+		added, err := addMember(tx, groupUuid, userUuid)
+		if err != nil {
+			return err
+		}
+
+		if !added {
+			return errAlreadyMember
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errInviteUnavailable) {
+		return false, false, nil
+	}
+
+	if errors.Is(err, errAlreadyMember) {
+		return true, false, nil
+	}
+
+	return err == nil, err == nil, err
+}
+
+// RequestByInvite 消耗一次邀请链接的使用次数并创建入群申请，链接不可用时返回 false
+func (this *GroupInviteRepository) RequestByInvite(inviteUuid string, request *model.GroupJoinRequest) (bool, error) {
+	db := db.GetDB()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := useInvite(tx, inviteUuid); err != nil {
+			return err
+		}
+
+		return tx.Create(request).Error
+	})
+
+	if errors.Is(err, errInviteUnavailable) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// useInvite 在事务中将邀请链接的使用次数加一，链接不可用时返回 errInviteUnavailable
+func useInvite(tx *gorm.DB, inviteUuid string) error {
+	result := activeInvites(tx.Model(&model.GroupInvite{}), time.Now()).
+		Where("uuid = ?", inviteUuid).
+		Update("use_count", gorm.Expr("use_count + 1"))
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errInviteUnavailable
+	}
+
+	return nil
+}
+
+// activeInvites 过滤出未撤销、未过期且未达到使用次数上限的邀请链接
+func activeInvites(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.
+		Where("revoked_at IS NULL").
+		Where("(expires_at IS NULL OR expires_at > ?)", now).
+		Where("(max_uses = 0 OR use_count < max_uses)")
+}
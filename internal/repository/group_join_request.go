@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type GroupJoinRequestRepository struct {
+}
+
+var GroupJoinRequestRepo = &GroupJoinRequestRepository{}
+
+func (this *GroupJoinRequestRepository) FindByUuid(uuid string) (*model.GroupJoinRequest, error) {
+	db := db.GetDB()
+	request := &model.GroupJoinRequest{}
+
+	result := db.Where("uuid = ?", uuid).First(request)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return request, result.Error
+}
+
+func (this *GroupJoinRequestRepository) Create(request *model.GroupJoinRequest) error {
+	db := db.GetDB()
+
+	return db.Create(request).Error
+}
+
+// FindByUuids 批量查询申请，不存在的 uuid 会被忽略
+func (this *GroupJoinRequestRepository) FindByUuids(uuids []string) ([]model.GroupJoinRequest, error) {
+	db := db.GetDB()
+	requests := []model.GroupJoinRequest{}
+
+	if len(uuids) == 0 {
+		return requests, nil
+	}
+
+	result := db.Where("uuid IN ?", uuids).Find(&requests)
+
+	return requests, result.Error
+}
+
+// FindLatestRejected 查询用户在群内最近一次被拒绝的申请
+func (this *GroupJoinRequestRepository) FindLatestRejected(groupUuid string, userUuid string) (*model.GroupJoinRequest, error) {
+	db := db.GetDB()
+	request := &model.GroupJoinRequest{}
+
+	result := db.
+		Where("group_uuid = ? AND user_uuid = ? AND status = ?", groupUuid, userUuid, model.GroupJoinRequestRejected).
+		Order("reviewed_at DESC").
+		First(request)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return request, result.Error
+}
+
+// FindByUser 分页查询用户发起的申请，按申请时间倒序
+func (this *GroupJoinRequestRepository) FindByUser(userUuid string, offset int, limit int) ([]model.GroupJoinRequest, int64, error) {
+	db := db.GetDB()
+	requests := []model.GroupJoinRequest{}
+	var total int64
+
+	query := db.Model(&model.GroupJoinRequest{}).Where("user_uuid = ?", userUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&requests)
+
+	return requests, total, result.Error
+}
+
+// FindPending 查询用户在群内待审批的申请
+func (this *GroupJoinRequestRepository) FindPending(groupUuid string, userUuid string) (*model.GroupJoinRequest, error) {
+	db := db.GetDB()
+	request := &model.GroupJoinRequest{}
+
+	result := db.
+		Where("group_uuid = ? AND user_uuid = ? AND status = ?", groupUuid, userUuid, model.GroupJoinRequestPending).
+		First(request)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return request, result.Error
+}
+
+// FindPendingByGroup 按申请时间分页查询群内待审批的申请
+func (this *GroupJoinRequestRepository) FindPendingByGroup(
+	groupUuid string,
+	offset int,
+	limit int,
+) ([]model.GroupJoinRequest, int64, error) {
+	db := db.GetDB()
+	requests := []model.GroupJoinRequest{}
+	var total int64
+
+	query := db.Model(&model.GroupJoinRequest{}).
+		Where("group_uuid = ? AND status = ?", groupUuid, model.GroupJoinRequestPending)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&requests)
+
+	return requests, total, result.Error
+}
+
+// Approve 通过申请并将申请人加入群聊，申请已被处理时返回 false
+func (this *GroupJoinRequestRepository) Approve(request *model.GroupJoinRequest, reviewerUuid string) (bool, error) {
+	db := db.GetDB()
+	approved := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		reviewed, err := review(tx, request, model.GroupJoinRequestApproved, reviewerUuid)
+		if err != nil || !reviewed {
+			return err
+		}
+		approved = true
+
+		_, err = addMember(tx, request.GroupUuid, request.UserUuid)
+		return err
+	})
+
+	return approved, err
+}
+
+// Reject 拒绝申请，申请已被处理时返回 false
+func (this *GroupJoinRequestRepository) Reject(request *model.GroupJoinRequest, reviewerUuid string) (bool, error) {
+	db := db.GetDB()
+
+	return review(db, request, model.GroupJoinRequestRejected, reviewerUuid)
+}
+
+// review 将待审批的申请更新为指定状态，通过状态条件保证同一申请只会被处理一次
+func review(tx *gorm.DB, request *model.GroupJoinRequest, status string, reviewerUuid string) (bool, error) {
+	now := time.Now()
+
+	result := tx.Model(&model.GroupJoinRequest{}).
+		Where("uuid = ? AND status = ?", request.Uuid, model.GroupJoinRequestPending).
+		Updates(map[string]any{
+			"status":        status,
+			"reviewer_uuid": reviewerUuid,
+			"reviewed_at":   now,
+		})
+
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+
+	request.Status = status
+	request.ReviewerUuid = reviewerUuid
+	request.ReviewedAt = &now
+
+	return true, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GroupModerationRepository struct {
+}
+
+var GroupModerationRepo = &GroupModerationRepository{}
+
+// Ban 禁止用户加入群聊，同时将其移出群聊，并拒绝其待审批的入群申请
+// 返回是否新增了禁止记录，以及用户是否被移出群聊，已被禁止时不做任何修改
+func (this *GroupModerationRepository) Ban(ban *model.GroupBan) (bool, bool, error) {
+	db := db.GetDB()
+	banned := false
+	removed := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(ban)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		banned = true
+
+		var err error
+		if removed, err = removeMember(tx, ban.GroupUuid, ban.UserUuid); err != nil {
+			return err
+		}
+
+		return tx.Model(&model.GroupJoinRequest{}).
+			Where("group_uuid = ? AND user_uuid = ? AND status = ?", ban.GroupUuid, ban.UserUuid, model.GroupJoinRequestPending).
+			Updates(map[string]any{
+				"status":        model.GroupJoinRequestRejected,
+				"reviewer_uuid": ban.OperatorUuid,
+				"reviewed_at":   time.Now(),
+			}).Error
+	})
+
+	return banned, removed, err
+}
+
+// Unban 解除禁止（物理删除），未被禁止时返回 false
+func (this *GroupModerationRepository) Unban(groupUuid string, userUuid string) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Unscoped().
+		Where("group_uuid = ? AND user_uuid = ?", groupUuid, userUuid).
+		Delete(&model.GroupBan{})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+func (this *GroupModerationRepository) FindBan(groupUuid string, userUuid string) (*model.GroupBan, error) {
+	db := db.GetDB()
+	ban := &model.GroupBan{}
+
+	result := db.Where("group_uuid = ? AND user_uuid = ?", groupUuid, userUuid).First(ban)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return ban, result.Error
+}
+
+// FindBannedUuids 查询指定用户中被禁止加入群聊的用户
+func (this *GroupModerationRepository) FindBannedUuids(groupUuid string, userUuids []string) ([]string, error) {
+	db := db.GetDB()
+	uuids := []string{}
+
+	if len(userUuids) == 0 {
+		return uuids, nil
+	}
+
+	result := db.Model(&model.GroupBan{}).
+		Where("group_uuid = ? AND user_uuid IN ?", groupUuid, userUuids).
+		Pluck("user_uuid", &uuids)
+
+	return uuids, result.Error
+}
+
+// FindBans 分页查询群内被禁止的用户，最近禁止的在前
+func (this *GroupModerationRepository) FindBans(groupUuid string, offset int, limit int) ([]model.GroupBan, int64, error) {
+	db := db.GetDB()
+	bans := []model.GroupBan{}
+	var total int64
+
+	query := db.Model(&model.GroupBan{}).Where("group_uuid = ?", groupUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&bans)
+
+	return bans, total, result.Error
+}
+
+func (this *GroupModerationRepository) CreateLog(log *model.GroupModerationLog) error {
+	db := db.GetDB()
+
+	return db.Create(log).Error
+}
+
+// FindLogs 分页查询群管理日志，最近的在前
+func (this *GroupModerationRepository) FindLogs(
+	groupUuid string,
+	offset int,
+	limit int,
+) ([]model.GroupModerationLog, int64, error) {
+	db := db.GetDB()
+	logs := []model.GroupModerationLog{}
+	var total int64
+
+	query := db.Model(&model.GroupModerationLog{}).Where("group_uuid = ?", groupUuid)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs)
+
+	return logs, total, result.Error
+}
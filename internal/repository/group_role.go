@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type GroupRoleRepository struct {
+}
+
+var GroupRoleRepo = &GroupRoleRepository{}
+
+// Create 创建自定义角色，同名角色已存在时返回 gorm.ErrDuplicatedKey
+func (this *GroupRoleRepository) Create(role *model.GroupRole) error {
+	db := db.GetDB()
+
+	return db.Create(role).Error
+}
+
+func (this *GroupRoleRepository) FindByName(groupUuid string, name string) (*model.GroupRole, error) {
+	db := db.GetDB()
+	role := &model.GroupRole{}
+
+	result := db.Where("group_uuid = ? AND name = ?", groupUuid, name).First(role)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return role, result.Error
+}
+
+// FindByGroup 查询群内所有自定义角色，按创建顺序排列
+func (this *GroupRoleRepository) FindByGroup(groupUuid string) ([]model.GroupRole, error) {
+	db := db.GetDB()
+	roles := []model.GroupRole{}
+
+	result := db.Where("group_uuid = ?", groupUuid).Order("id ASC").Find(&roles)
+
+	return roles, result.Error
+}
+
+func (this *GroupRoleRepository) UpdatePermissions(role *model.GroupRole, permissions model.GroupPermission) error {
+	db := db.GetDB()
+
+	return db.Model(role).Update("permissions", permissions).Error
+}
+
+// Delete 删除自定义角色（物理删除），拥有该角色的成员恢复为普通成员
+func (this *GroupRoleRepository) Delete(role *model.GroupRole) error {
+	db := db.GetDB()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(role).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.GroupMember{}).
+			Where("group_uuid = ? AND role = ?", role.GroupUuid, role.Name).
+			Update("role", model.GroupRoleMember).Error
+	})
+}
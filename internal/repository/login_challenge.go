@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type LoginChallengeRepository struct {
+}
+
+var LoginChallengeRepo = &LoginChallengeRepository{}
+
+func (this *LoginChallengeRepository) Create(challenge *model.LoginChallenge) error {
+	db := db.GetDB()
+
+	return db.Create(challenge).Error
+}
+
+func (this *LoginChallengeRepository) FindByHash(tokenHash string) (*model.LoginChallenge, error) {
+	db := db.GetDB()
+	challenge := &model.LoginChallenge{}
+
+	result := db.Where("token_hash = ?", tokenHash).First(challenge)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return challenge, result.Error
+}
+
+// ReserveAttempt 在校验验证码前占用一次验证次数，次数已用尽时返回 false
+// 通过 attempts 条件保证并发请求也不会超过上限
+func (this *LoginChallengeRepository) ReserveAttempt(id uint, maxAttempts int) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.LoginChallenge{}).
+		Where("id = ? AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// Consume 删除凭证（物理删除），凭证已被其他请求删除时返回 false，保证同一凭证只能使用一次
+func (this *LoginChallengeRepository) Consume(id uint) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Unscoped().Where("id = ?", id).Delete(&model.LoginChallenge{})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteExpired 删除已过期的凭证（物理删除）
+func (this *LoginChallengeRepository) DeleteExpired(now time.Time) error {
+	db := db.GetDB()
+
+	return db.Unscoped().Where("expires_at <= ?", now).Delete(&model.LoginChallenge{}).Error
+}
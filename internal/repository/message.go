@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/pkg/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MessageRepository struct {
+}
+
+var MessageRepo = &MessageRepository{}
+
+// CreateMessage 保存消息，并同步更新会话的最后消息时间
+// 话题回复还会更新根消息的回复数、最后回复时间，并将根消息发送者和回复者记录为话题参与者
+func (this *MessageRepository) CreateMessage(message *model.Message, root *model.Message) error {
+	db := db.GetDB()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+
+		if root != nil {
+			result := tx.Model(&model.Message{}).
+				Unscoped().
+				Where("uuid = ?", root.Uuid).
+				Updates(map[string]any{
+					"reply_count":   gorm.Expr("reply_count + 1"),
+					"last_reply_at": message.CreatedAt,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+
+			participants := []model.ThreadParticipant{
+				{ThreadRootUuid: root.Uuid, UserUuid: root.SenderUuid},
+				{ThreadRootUuid: root.Uuid, UserUuid: message.SenderUuid},
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&participants).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&model.Conversation{}).
+			Where("uuid = ?", message.ConversationUuid).
+			Update("last_message_at", message.CreatedAt).Error
+	})
+}
+
+// FindPage 基于游标查询会话消息，按 (created_at, id) 排序保证同一时刻的消息顺序稳定
+// after 为 false 时向前翻页（更早的消息），按时间倒序返回；为 true 时向后翻页，按时间正序返回
+// cursor 为 nil 时从最新（或最早）的消息开始
+// 已撤回的消息作为墓碑返回，以保证翻页游标稳定；viewerUuid 自己删除的消息不返回
+// threadRootUuid 为空时查询会话主时间线，否则查询该话题的回复
+func (this *MessageRepository) FindPage(
+	conversationUuid string,
+	threadRootUuid string,
+	viewerUuid string,
+	cursor *common.Cursor,
+	after bool,
+	limit int,
+) ([]model.Message, error) {
+	db := db.GetDB()
+	messages := []model.Message{}
+
+	query := db.Unscoped().
+		Where("conversation_uuid = ? AND thread_root_uuid = ?", conversationUuid, threadRootUuid).
+		Where(
+			"NOT EXISTS (SELECT 1 FROM message_hiddens WHERE message_hiddens.message_uuid = messages.uuid AND message_hiddens.user_uuid = ?)",
+			viewerUuid,
+		)
+
+	if after {
+		if cursor != nil {
+			query = query.Where(
+				"(created_at > ? OR (created_at = ? AND id > ?))",
+				cursor.CreatedAt, cursor.CreatedAt, cursor.Id,
+			)
+		}
+		query = query.Order("created_at ASC, id ASC")
+	} else {
+		if cursor != nil {
+			query = query.Where(
+				"(created_at < ? OR (created_at = ? AND id < ?))",
+				cursor.CreatedAt, cursor.CreatedAt, cursor.Id,
+			)
+		}
+		query = query.Order("created_at DESC, id DESC")
+	}
+
+	result := query.Limit(limit).Find(&messages)
+
+	return messages, result.Error
+}
+
+func (this *MessageRepository) FindByUuid(uuid string) (*model.Message, error) {
+	db := db.GetDB()
+	message := &model.Message{}
+
+	result := db.Where("uuid = ?", uuid).First(message)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return message, result.Error
+}
+
+// FindSenderUuidsInRange 查询会话中 ID 位于 (fromId, toId] 区间内消息的发送者，排除指定用户
+func (this *MessageRepository) FindSenderUuidsInRange(
+	conversationUuid string,
+	fromId uint,
+	toId uint,
+	excludeUuid string,
+) ([]string, error) {
+	db := db.GetDB()
+	uuids := []string{}
+
+	result := db.Model(&model.Message{}).
+		Where("conversation_uuid = ? AND id > ? AND id <= ? AND sender_uuid <> ?", conversationUuid, fromId, toId, excludeUuid).
+		Distinct().
+		Pluck("sender_uuid", &uuids)
+
+	return uuids, result.Error
+}
+
+// FindByUuidWithDeleted 查询消息，包括已撤回的消息
+func (this *MessageRepository) FindByUuidWithDeleted(uuid string) (*model.Message, error) {
+	db := db.GetDB()
+	message := &model.Message{}
+
+	result := db.Unscoped().Where("uuid = ?", uuid).First(message)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return message, result.Error
+}
+
+// FindByUuidsWithDeleted 批量查询消息，包括已撤回的消息
+func (this *MessageRepository) FindByUuidsWithDeleted(uuids []string) ([]model.Message, error) {
+	db := db.GetDB()
+	messages := []model.Message{}
+
+	if len(uuids) == 0 {
+		return messages, nil
+	}
+
+	result := db.Unscoped().Where("uuid IN ?", uuids).Find(&messages)
+
+	return messages, result.Error
+}
+
+// FindThreadParticipants 批量查询话题参与者，按加入顺序排列
+func (this *MessageRepository) FindThreadParticipants(rootUuids []string) ([]model.ThreadParticipant, error) {
+	db := db.GetDB()
+	participants := []model.ThreadParticipant{}
+
+	if len(rootUuids) == 0 {
+		return participants, nil
+	}
+
+	result := db.Where("thread_root_uuid IN ?", rootUuids).Order("id ASC").Find(&participants)
+
+	return participants, result.Error
+}
+
+// UpdateContent 编辑消息内容，并将编辑前的内容保存为历史版本
+func (this *MessageRepository) UpdateContent(message *model.Message, content string, editedAt time.Time) error {
+	db := db.GetDB()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		revision := &model.MessageRevision{
+			MessageUuid: message.Uuid,
+			Content:     message.Content,
+		}
+
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(message).Updates(map[string]any{
+			"content":   content,
+			"edited_at": editedAt,
+		})
+
+		return result.Error
+	})
+}
+
+// FindRevisions 查询消息的历史版本，按编辑时间正序排列
+func (this *MessageRepository) FindRevisions(messageUuid string) ([]model.MessageRevision, error) {
+	db := db.GetDB()
+	revisions := []model.MessageRevision{}
+
+	result := db.Where("message_uuid = ?", messageUuid).Order("id ASC").Find(&revisions)
+
+	return revisions, result.Error
+}
+
+// DeleteForEveryone 撤回消息：清空内容、历史版本和表情回应后软删除，保留记录作为墓碑
+func (this *MessageRepository) DeleteForEveryone(message *model.Message) error {
+	db := db.GetDB()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(message).Update("content", "").Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("message_uuid = ?", message.Uuid).Delete(&model.MessageRevision{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("message_uuid = ?", message.Uuid).Delete(&model.MessageReaction{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(message).Error
+	})
+}
+
+// Hide 对用户隐藏消息，重复隐藏时忽略
+func (this *MessageRepository) Hide(messageUuid string, userUuid string) error {
+	db := db.GetDB()
+
+	hidden := &model.MessageHidden{
+		MessageUuid: messageUuid,
+		UserUuid:    userUuid,
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(hidden)
+
+	return result.Error
+}
+
+// UpdatePinned 置顶或取消置顶消息，状态没有变化时返回 false
+func (this *MessageRepository) UpdatePinned(message *model.Message, pinned bool, operatorUuid string) (bool, error) {
+	db := db.GetDB()
+
+	query := db.Model(&model.Message{}).Where("uuid = ?", message.Uuid)
+	updates := map[string]any{"pinned_at": nil, "pinned_by_uuid": ""}
+
+	if pinned {
+		now := time.Now()
+		query = query.Where("pinned_at IS NULL")
+		updates = map[string]any{"pinned_at": now, "pinned_by_uuid": operatorUuid}
+		message.PinnedAt = &now
+		message.PinnedByUuid = operatorUuid
+	} else {
+		query = query.Where("pinned_at IS NOT NULL")
+		message.PinnedAt = nil
+		message.PinnedByUuid = ""
+	}
+
+	result := query.Updates(updates)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindPinned 查询会话中置顶的消息，按置顶时间倒序
+func (this *MessageRepository) FindPinned(conversationUuid string) ([]model.Message, error) {
+	db := db.GetDB()
+	messages := []model.Message{}
+
+	result := db.
+		Where("conversation_uuid = ? AND pinned_at IS NOT NULL", conversationUuid).
+		Order("pinned_at DESC").
+		Find(&messages)
+
+	return messages, result.Error
+}
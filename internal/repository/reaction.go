@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository struct {
+}
+
+var ReactionRepo = &ReactionRepository{}
+
+// errReactionLimitExceeded 用于在表情数量超过上限时回滚事务
+var errReactionLimitExceeded = errors.New("reaction limit exceeded")
+
+// ReactionSummary 是某条消息上某个表情的聚合结果
+type ReactionSummary struct {
+	MessageUuid string
+	Emoji       string
+	Count       int
+	// 查看者是否回应过该表情
+	Reacted bool
+}
+
+// Add 添加表情回应，已存在时忽略，返回是否新增
+func (this *ReactionRepository) Add(reaction *model.MessageReaction) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// AddWithLimit 添加表情回应，消息上不同表情的数量不能超过 maxEmojis
+// 返回是否新增，以及是否因超过上限而未添加，已存在时忽略
+// 统计和插入在同一事务中进行，并对消息加行锁，并发添加不同表情不会超过上限
+func (this *ReactionRepository) AddWithLimit(reaction *model.MessageReaction, maxEmojis int) (bool, bool, error) {
+	db := db.GetDB()
+	added := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 锁定消息行，同一消息的并发添加排队执行
+		var messageIds []uint
+		if err := tx.Model(&model.Message{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ?", reaction.MessageUuid).
+			Pluck("id", &messageIds).Error; err != nil {
+			return err
+		}
+
+		emojis := []string{}
+		if err := tx.Model(&model.MessageReaction{}).
+			Where("message_uuid = ?", reaction.MessageUuid).
+			Distinct().
+			Pluck("emoji", &emojis).Error; err != nil {
+			return err
+		}
+
+		if !slices.Contains(emojis, reaction.Emoji) && len(emojis) >= maxEmojis {
+			return errReactionLimitExceeded
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+		added = result.RowsAffected > 0
+		return result.Error
+	})
+
+	if errors.Is(err, errReactionLimitExceeded) {
+		return false, true, nil
+	}
+
+	return added, false, err
+}
+
+// Remove 移除表情回应，返回是否删除了记录
+// 由于唯一索引的存在，这里使用物理删除
+func (this *ReactionRepository) Remove(messageUuid string, userUuid string, emoji string) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Unscoped().
+		Where("message_uuid = ? AND user_uuid = ? AND emoji = ?", messageUuid, userUuid, emoji).
+		Delete(&model.MessageReaction{})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountByEmoji 统计消息上某个表情的回应人数
+func (this *ReactionRepository) CountByEmoji(messageUuid string, emoji string) (int64, error) {
+	db := db.GetDB()
+	var count int64
+
+	result := db.Model(&model.MessageReaction{}).
+		Where("message_uuid = ? AND emoji = ?", messageUuid, emoji).
+		Count(&count)
+
+	return count, result.Error
+}
+
+// FindSummaries 按消息和表情聚合回应人数，表情按首次出现的顺序排列
+func (this *ReactionRepository) FindSummaries(messageUuids []string, viewerUuid string) ([]ReactionSummary, error) {
+	db := db.GetDB()
+	summaries := []ReactionSummary{}
+
+	if len(messageUuids) == 0 {
+		return summaries, nil
+	}
+
+	result := db.Model(&model.MessageReaction{}).
+		Select("message_uuid, emoji, COUNT(*) AS count, MAX(user_uuid = ?) AS reacted", viewerUuid).
+		Where("message_uuid IN ?", messageUuids).
+		Group("message_uuid, emoji").
+		Order("MIN(id) ASC").
+		Scan(&summaries)
+
+	return summaries, result.Error
+}
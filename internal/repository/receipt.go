@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReceiptRepository struct {
+}
+
+var ReceiptRepo = &ReceiptRepository{}
+
+// Advance 推进用户在会话中的送达/已读水位线，水位线只会前进不会后退
+// 已读隐含已送达，返回推进前后的回执记录，用于计算本次新送达/新已读的消息范围
+func (this *ReceiptRepository) Advance(
+	conversationUuid string,
+	userUuid string,
+	messageId uint,
+	read bool,
+	at time.Time,
+) (before model.MessageReceipt, after model.MessageReceipt, err error) {
+	db := db.GetDB()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		receipts := []model.MessageReceipt{}
+
+		// 记录不存在时先创建空的回执记录，否则 SELECT ... FOR UPDATE 锁不住任何行，
+		// 多个设备同时首次回执会因唯一索引冲突而失败
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.MessageReceipt{ConversationUuid: conversationUuid, UserUuid: userUuid})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// 加行锁，避免同一用户多个设备同时回执导致水位线回退
+		result = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("conversation_uuid = ? AND user_uuid = ?", conversationUuid, userUuid).
+			Limit(1).
+			Find(&receipts)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(receipts) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		receipt := receipts[0]
+		before = receipt
+
+		if messageId > receipt.DeliveredMessageId {
+			receipt.DeliveredMessageId = messageId
+			receipt.DeliveredAt = &at
+		}
+		if read && messageId > receipt.ReadMessageId {
+			receipt.ReadMessageId = messageId
+			receipt.ReadAt = &at
+		}
+		after = receipt
+
+		return tx.Save(&receipt).Error
+	})
+
+	return before, after, err
+}
+
+// CountByMessage 统计消息的送达人数和已读人数（不含发送者）
+// 关闭了已读回执的用户不计入已读人数
+func (this *ReceiptRepository) CountByMessage(
+	conversationUuid string,
+	senderUuid string,
+	messageId uint,
+) (delivered int64, read int64, err error) {
+	db := db.GetDB()
+
+	err = db.Model(&model.MessageReceipt{}).
+		Where("conversation_uuid = ? AND user_uuid <> ? AND delivered_message_id >= ?", conversationUuid, senderUuid, messageId).
+		Count(&delivered).Error
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = this.readersQuery(conversationUuid, senderUuid, messageId).Count(&read).Error
+
+	return delivered, read, err
+}
+
+// FindReaders 按已读时间分页查询已读消息的用户（不含发送者和关闭了已读回执的用户）
+func (this *ReceiptRepository) FindReaders(
+	conversationUuid string,
+	senderUuid string,
+	messageId uint,
+	offset int,
+	limit int,
+) ([]model.MessageReceipt, error) {
+	receipts := []model.MessageReceipt{}
+
+	result := this.readersQuery(conversationUuid, senderUuid, messageId).
+		Order("message_receipts.read_at ASC, message_receipts.id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&receipts)
+
+	return receipts, result.Error
+}
+
+func (this *ReceiptRepository) readersQuery(conversationUuid string, senderUuid string, messageId uint) *gorm.DB {
+	db := db.GetDB()
+
+	return db.Model(&model.MessageReceipt{}).
+		Joins("LEFT JOIN user_settings ON user_settings.user_uuid = message_receipts.user_uuid AND user_settings.deleted_at IS NULL").
+		Where("message_receipts.conversation_uuid = ?", conversationUuid).
+		Where("message_receipts.user_uuid <> ?", senderUuid).
+		Where("message_receipts.read_message_id >= ?", messageId).
+		Where("(user_settings.id IS NULL OR user_settings.read_receipts = ?)", true)
+}
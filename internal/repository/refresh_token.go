@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+}
+
+var RefreshTokenRepo = &RefreshTokenRepository{}
+
+func (this *RefreshTokenRepository) Create(token *model.RefreshToken) error {
+	db := db.GetDB()
+
+	return db.Create(token).Error
+}
+
+func (this *RefreshTokenRepository) FindByHash(tokenHash string) (*model.RefreshToken, error) {
+	db := db.GetDB()
+	token := &model.RefreshToken{}
+
+	result := db.Where("token_hash = ?", tokenHash).First(token)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return token, result.Error
+}
+
+// Rotate 将旧 token 标记为已使用并保存新 token
+// 通过 used_at 条件保证同一 token 只能轮换一次，已被使用或已被吊销时返回 false
+func (this *RefreshTokenRepository) Rotate(old *model.RefreshToken, next *model.RefreshToken) (bool, error) {
+	db := db.GetDB()
+	rotated := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", old.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		rotated = true
+
+		return tx.Create(next).Error
+	})
+
+	return rotated, err
+}
+
+// DeleteExpired 删除已过期的 token（物理删除）
+func (this *RefreshTokenRepository) DeleteExpired(now time.Time) error {
+	db := db.GetDB()
+
+	return db.Unscoped().Where("expires_at <= ?", now).Delete(&model.RefreshToken{}).Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+}
+
+var SessionRepo = &SessionRepository{}
+
+func (this *SessionRepository) Create(session *model.Session) error {
+	db := db.GetDB()
+
+	return db.Create(session).Error
+}
+
+func (this *SessionRepository) FindByUuid(uuid string) (*model.Session, error) {
+	db := db.GetDB()
+	session := &model.Session{}
+
+	result := db.Where("uuid = ?", uuid).First(session)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return session, result.Error
+}
+
+// FindActiveByUser 查询用户未吊销且未过期的会话，最近使用的在前
+func (this *SessionRepository) FindActiveByUser(userUuid string, now time.Time) ([]model.Session, error) {
+	db := db.GetDB()
+	var sessions []model.Session
+
+	result := db.Where("user_uuid = ? AND revoked_at IS NULL AND expires_at > ?", userUuid, now).
+		Order("last_used_at DESC").
+		Find(&sessions)
+
+	return sessions, result.Error
+}
+
+// UpdateLastUsed 更新会话的最近使用时间
+func (this *SessionRepository) UpdateLastUsed(uuid string, lastUsedAt time.Time) error {
+	db := db.GetDB()
+
+	return db.Model(&model.Session{}).Where("uuid = ?", uuid).Update("last_used_at", lastUsedAt).Error
+}
+
+// Renew 刷新 Token 后更新会话的最近使用时间和过期时间
+func (this *SessionRepository) Renew(uuid string, lastUsedAt time.Time, expiresAt time.Time) error {
+	db := db.GetDB()
+
+	return db.Model(&model.Session{}).Where("uuid = ?", uuid).Updates(map[string]any{
+		"last_used_at": lastUsedAt,
+		"expires_at":   expiresAt,
+	}).Error
+}
+
+// Revoke 吊销会话，同时吊销会话中所有尚未吊销的 Refresh Token
+func (this *SessionRepository) Revoke(uuids []string) error {
+	db := db.GetDB()
+	now := time.Now()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Session{}).
+			Where("uuid IN ? AND revoked_at IS NULL", uuids).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.RefreshToken{}).
+			Where("family_uuid IN ? AND revoked_at IS NULL", uuids).
+			Update("revoked_at", now).Error
+	})
+}
+
+// DeleteExpired 删除已过期的会话（物理删除）
+func (this *SessionRepository) DeleteExpired(now time.Time) error {
+	db := db.GetDB()
+
+	return db.Unscoped().Where("expires_at <= ?", now).Delete(&model.Session{}).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+type SettingRepository struct {
+}
+
+var SettingRepo = &SettingRepository{}
+
+// FindByUser 查询用户设置，用户尚未修改过设置时返回默认值
+func (this *SettingRepository) FindByUser(userUuid string) (*model.UserSetting, error) {
+	db := db.GetDB()
+	settings := []model.UserSetting{}
+
+	result := db.Where("user_uuid = ?", userUuid).Limit(1).Find(&settings)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(settings) == 0 {
+		return model.DefaultUserSetting(userUuid), nil
+	}
+
+	return &settings[0], nil
+}
+
+// FindByUsers 批量查询用户设置，key 为用户 uuid，尚未修改过设置的用户返回默认值
+func (this *SettingRepository) FindByUsers(userUuids []string) (map[string]*model.UserSetting, error) {
+	db := db.GetDB()
+	settingMap := make(map[string]*model.UserSetting, len(userUuids))
+
+	if len(userUuids) == 0 {
+		return settingMap, nil
+	}
+
+	settings := []model.UserSetting{}
+
+	if err := db.Where("user_uuid IN ?", userUuids).Find(&settings).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range settings {
+		settingMap[settings[i].UserUuid] = &settings[i]
+	}
+
+	for _, userUuid := range userUuids {
+		if _, ok := settingMap[userUuid]; !ok {
+			settingMap[userUuid] = model.DefaultUserSetting(userUuid)
+		}
+	}
+
+	return settingMap, nil
+}
+
+// UpdatesByUser 更新用户设置，记录不存在时先按默认值创建
+func (this *SettingRepository) UpdatesByUser(userUuid string, updates map[string]any) (*model.UserSetting, error) {
+	db := db.GetDB()
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(model.DefaultUserSetting(userUuid))
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(updates) > 0 {
+		result = db.Model(&model.UserSetting{}).Where("user_uuid = ?", userUuid).Updates(updates)
+
+		if result.Error != nil {
+			return nil, result.Error
+		}
+	}
+
+	return this.FindByUser(userUuid)
+}
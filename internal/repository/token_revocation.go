@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+// TokenRevocationStore 保存被吊销的 Access Token 的 jti
+// Token 过期后本身就无法通过校验，因此吊销记录只需要保存到 Token 过期为止
+type TokenRevocationStore interface {
+	// Revoke 吊销 Token，expiresAt 为 Token 的过期时间
+	Revoke(jti string, expiresAt time.Time) error
+	IsRevoked(jti string) (bool, error)
+	// Prune 清理 Token 已过期的吊销记录
+	Prune(now time.Time) error
+}
+
+// MemoryTokenRevocationStore 将吊销记录保存在内存中，重启后丢失，只适用于单实例部署
+type MemoryTokenRevocationStore struct {
+	mu      sync.RWMutex
+	entries map[string]time.Time
+}
+
+func NewMemoryTokenRevocationStore() *MemoryTokenRevocationStore {
+	return &MemoryTokenRevocationStore{entries: make(map[string]time.Time)}
+}
+
+func (this *MemoryTokenRevocationStore) Revoke(jti string, expiresAt time.Time) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.entries[jti] = expiresAt
+	return nil
+}
+
+func (this *MemoryTokenRevocationStore) IsRevoked(jti string) (bool, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	_, ok := this.entries[jti]
+	return ok, nil
+}
+
+func (this *MemoryTokenRevocationStore) Prune(now time.Time) error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	for jti, expiresAt := range this.entries {
+		if !expiresAt.After(now) {
+			delete(this.entries, jti)
+		}
+	}
+	return nil
+}
+
+// DBTokenRevocationStore 将吊销记录保存在数据库中，多个实例之间共享
+type DBTokenRevocationStore struct {
+}
+
+func (this *DBTokenRevocationStore) Revoke(jti string, expiresAt time.Time) error {
+	db := db.GetDB()
+
+	token := &model.RevokedToken{Jti: jti, ExpiresAt: expiresAt}
+
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (this *DBTokenRevocationStore) IsRevoked(jti string) (bool, error) {
+	db := db.GetDB()
+	var count int64
+
+	result := db.Model(&model.RevokedToken{}).Where("jti = ?", jti).Count(&count)
+
+	return count > 0, result.Error
+}
+
+// Prune 清理 Token 已过期的吊销记录（物理删除）
+func (this *DBTokenRevocationStore) Prune(now time.Time) error {
+	db := db.GetDB()
+
+	return db.Unscoped().Where("expires_at <= ?", now).Delete(&model.RevokedToken{}).Error
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/db"
+	"github.com/shy-robin/gochat/internal/model"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository struct {
+}
+
+var TwoFactorRepo = &TwoFactorRepository{}
+
+func (this *TwoFactorRepository) FindByUser(userUuid string) (*model.UserTwoFactor, error) {
+	db := db.GetDB()
+	twoFactor := &model.UserTwoFactor{}
+
+	result := db.Where("user_uuid = ?", userUuid).First(twoFactor)
+
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return twoFactor, result.Error
+}
+
+// SavePending 保存未确认的密钥，替换之前未确认的密钥（物理删除）
+func (this *TwoFactorRepository) SavePending(twoFactor *model.UserTwoFactor) error {
+	db := db.GetDB()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("user_uuid = ? AND enabled_at IS NULL", twoFactor.UserUuid).
+			Delete(&model.UserTwoFactor{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(twoFactor).Error
+	})
+}
+
+// Enable 确认密钥并开启两步验证，同时替换用户的恢复码
+// 通过 enabled_at 条件保证只能确认一次，已开启时返回 false
+func (this *TwoFactorRepository) Enable(
+	twoFactor *model.UserTwoFactor,
+	step int64,
+	codes []model.RecoveryCode,
+) (bool, error) {
+	db := db.GetDB()
+	enabled := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.UserTwoFactor{}).
+			Where("id = ? AND enabled_at IS NULL", twoFactor.ID).
+			Updates(map[string]any{"enabled_at": time.Now(), "last_used_step": step})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		enabled = true
+
+		if err := tx.Unscoped().
+			Where("user_uuid = ?", twoFactor.UserUuid).
+			Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+
+	return enabled, err
+}
+
+// ReserveAttempt 在校验验证码前占用一次失败次数，已锁定或失败次数已达上限时返回 false
+// 先占用再校验，保证并发请求也不会超过上限
+func (this *TwoFactorRepository) ReserveAttempt(userUuid string, maxAttempts int, now time.Time) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.UserTwoFactor{}).
+		Where("user_uuid = ? AND failed_attempts < ?", userUuid, maxAttempts).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Update("failed_attempts", gorm.Expr("failed_attempts + 1"))
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// ResetAttempts 校验成功后清零失败次数
+func (this *TwoFactorRepository) ResetAttempts(userUuid string) error {
+	db := db.GetDB()
+
+	return db.Model(&model.UserTwoFactor{}).
+		Where("user_uuid = ?", userUuid).
+		Updates(map[string]any{"failed_attempts": 0, "locked_until": nil}).Error
+}
+
+// LockIfExceeded 失败次数达到上限时锁定到 until，并清零失败次数，返回是否锁定
+func (this *TwoFactorRepository) LockIfExceeded(userUuid string, maxAttempts int, until time.Time) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.UserTwoFactor{}).
+		Where("user_uuid = ? AND failed_attempts >= ?", userUuid, maxAttempts).
+		Updates(map[string]any{"failed_attempts": 0, "locked_until": until})
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// UseStep 记录已使用的时间步，时间步不大于上次使用的时间步时返回 false
+func (this *TwoFactorRepository) UseStep(userUuid string, step int64) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.UserTwoFactor{}).
+		Where("user_uuid = ? AND last_used_step < ?", userUuid, step).
+		Update("last_used_step", step)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// UseRecoveryCode 将恢复码标记为已使用，恢复码不存在或已被使用时返回 false
+func (this *TwoFactorRepository) UseRecoveryCode(userUuid string, codeHash string) (bool, error) {
+	db := db.GetDB()
+
+	result := db.Model(&model.RecoveryCode{}).
+		Where("user_uuid = ? AND code_hash = ? AND used_at IS NULL", userUuid, codeHash).
+		Update("used_at", time.Now())
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountUnusedRecoveryCodes 统计用户剩余可用的恢复码数量
+func (this *TwoFactorRepository) CountUnusedRecoveryCodes(userUuid string) (int64, error) {
+	db := db.GetDB()
+	var count int64
+
+	result := db.Model(&model.RecoveryCode{}).
+		Where("user_uuid = ? AND used_at IS NULL", userUuid).
+		Count(&count)
+
+	return count, result.Error
+}
+
+// Delete 关闭两步验证，删除密钥和恢复码（物理删除）
+func (this *TwoFactorRepository) Delete(userUuid string) error {
+	db := db.GetDB()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_uuid = ?", userUuid).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("user_uuid = ?", userUuid).Delete(&model.UserTwoFactor{}).Error
+	})
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/shy-robin/gochat/internal/db"
-	"github.com/shy-robin/gochat/internal/handler/v1/dto"
 	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/pkg/common"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -58,17 +60,17 @@ func (this *UserRepository) FindByUuid(uuid string) (*model.User, error) {
 
 func (this *UserRepository) UpdatesByUuid(
 	uuid string,
-	updates dto.ModifyUserInfoRequest,
+	updates map[string]any,
 ) (*model.User, error) {
 	db := db.GetDB()
 
-	if updates.Password != "" {
-		hashedPassword, hashErr := bcrypt.GenerateFromPassword([]byte(updates.Password), bcrypt.DefaultCost)
+	if password, ok := updates["password"].(string); ok {
+		hashedPassword, hashErr := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 		if hashErr != nil {
 			return nil, hashErr
 		}
 		// 替换明文密码
-		updates.Password = string(hashedPassword)
+		updates["password"] = string(hashedPassword)
 	}
 	// 需要加 Model 才能更新关联关系，否则无法找到对应的表
 	result := db.Model(&model.User{}).Where("uuid = ?", uuid).Updates(updates)
@@ -86,3 +88,125 @@ func (this *UserRepository) UpdatesByUuid(
 
 	return user, res.Error
 }
+
+// FindByUuids 批量查询用户，不存在的 uuid 会被忽略
+func (this *UserRepository) FindByUuids(uuids []string) ([]model.User, error) {
+	db := db.GetDB()
+	users := []model.User{}
+
+	if len(uuids) == 0 {
+		return users, nil
+	}
+
+	result := db.Where("uuid IN ?", uuids).Find(&users)
+
+	return users, result.Error
+}
+
+func (this *UserRepository) UpdateLastSeenAt(uuid string, lastSeenAt time.Time) error {
+	db := db.GetDB()
+
+	result := db.Model(&model.User{}).Where("uuid = ?", uuid).Update("last_seen_at", lastSeenAt)
+
+	return result.Error
+}
+
+// Search 按用户名或昵称前缀搜索用户，基于 (created_at, id) 游标分页，返回当前页以及匹配的总数
+// 排除查看者自己、关闭了搜索的用户，以及与查看者存在屏蔽关系的用户
+func (this *UserRepository) Search(
+	viewerUuid string,
+	keyword string,
+	cursor *common.Cursor,
+	limit int,
+) ([]model.User, int64, error) {
+	db := db.GetDB()
+	users := []model.User{}
+	var total int64
+
+	prefix := escapeLike(keyword) + "%"
+
+	query := db.Model(&model.User{}).
+		Where("(username LIKE ? OR nickname LIKE ?)", prefix, prefix).
+		Where("uuid <> ?", viewerUuid).
+		Where("NOT EXISTS (SELECT 1 FROM user_settings WHERE user_settings.user_uuid = users.uuid AND user_settings.searchable = ? AND user_settings.deleted_at IS NULL)", false).
+		Where(
+			"NOT EXISTS (SELECT 1 FROM user_blocks WHERE (user_blocks.blocker_uuid = users.uuid AND user_blocks.blocked_uuid = ?) OR (user_blocks.blocker_uuid = ? AND user_blocks.blocked_uuid = users.uuid))",
+			viewerUuid, viewerUuid,
+		)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if cursor != nil {
+		query = query.Where(
+			"(created_at > ? OR (created_at = ? AND id > ?))",
+			cursor.CreatedAt, cursor.CreatedAt, cursor.Id,
+		)
+	}
+
+	result := query.
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&users)
+
+	return users, total, result.Error
+}
+
+// FindDiscoverableByEmailHashes 按邮箱哈希查询允许被发现的用户
+// 排除查看者自己、邮箱未验证的用户、关闭了联系人发现的用户，以及与查看者存在屏蔽关系的用户
+func (this *UserRepository) FindDiscoverableByEmailHashes(viewerUuid string, hashes []string) ([]model.User, error) {
+	db := db.GetDB()
+	users := []model.User{}
+
+	if len(hashes) == 0 {
+		return users, nil
+	}
+
+	result := db.
+		Where("email_hash IN ?", hashes).
+		Where("uuid <> ?", viewerUuid).
+		Where("email_verified = ?", true).
+		Where("NOT EXISTS (SELECT 1 FROM user_settings WHERE user_settings.user_uuid = users.uuid AND user_settings.discoverable = ? AND user_settings.deleted_at IS NULL)", false).
+		Where(
+			"NOT EXISTS (SELECT 1 FROM user_blocks WHERE (user_blocks.blocker_uuid = users.uuid AND user_blocks.blocked_uuid = ?) OR (user_blocks.blocker_uuid = ? AND user_blocks.blocked_uuid = users.uuid))",
+			viewerUuid, viewerUuid,
+		).
+		Find(&users)
+
+	return users, result.Error
+}
+
+// escapeLike 转义 LIKE 语句中的通配符
+func escapeLike(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(keyword)
+}
+
+// FindExpiredStatusUuids 查询自定义状态已过期的用户
+func (this *UserRepository) FindExpiredStatusUuids(now time.Time) ([]string, error) {
+	db := db.GetDB()
+	uuids := []string{}
+
+	result := db.Model(&model.User{}).
+		Where("status_expires_at <= ?", now).
+		Pluck("uuid", &uuids)
+
+	return uuids, result.Error
+}
+
+// ClearExpiredStatuses 清空用户已过期的自定义状态，查询之后重新设置了状态的用户不受影响
+func (this *UserRepository) ClearExpiredStatuses(uuids []string, now time.Time) error {
+	db := db.GetDB()
+
+	if len(uuids) == 0 {
+		return nil
+	}
+
+	return db.Model(&model.User{}).
+		Where("uuid IN ? AND status_expires_at <= ?", uuids, now).
+		Updates(map[string]any{
+			"status_text":       "",
+			"status_emoji":      "",
+			"status_expires_at": nil,
+		}).Error
+}
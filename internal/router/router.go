@@ -49,8 +49,8 @@ func NewRouter() *gin.Engine {
 			"http://localhost:3000",
 			// "https://your-frontend-domain.com", // 允许的前端域名
 		},
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}, // 允许的方法
-		AllowHeaders: []string{"Origin", "Content-Type", "Authorization"}, // 允许的头部
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, // 允许的方法
+		AllowHeaders: []string{"Origin", "Content-Type", "Authorization"},          // 允许的头部
 
 		// 核心配置项：设置预检请求的缓存时间为 12 小时 (43200 秒)
 		MaxAge: 12 * time.Hour,
@@ -63,12 +63,31 @@ func NewRouter() *gin.Engine {
 		group1 := ginServer.Group("/api/v1")
 
 		group1.POST("/users", wrapper.WrapGinHandler(v1.Register))
+		group1.POST("/email/verify", wrapper.WrapGinHandler(v1.VerifyEmail))
 		group1.POST("/sessions", wrapper.WrapGinHandler(v1.Login))
+		group1.POST("/sessions/refresh", wrapper.WrapGinHandler(v1.RefreshSession))
+		group1.POST(
+			"/sessions/2fa",
+			middleware.RateLimitMiddleware(twoFactorRateLimit()),
+			wrapper.WrapGinHandler(v1.VerifyTwoFactor),
+		)
+		group1.GET("/sessions", middleware.JWTAuthMiddleware(), wrapper.WrapGinHandler(v1.GetSessions))
+		group1.DELETE("/sessions/current", middleware.JWTAuthMiddleware(), wrapper.WrapGinHandler(v1.Logout))
+		group1.DELETE("/sessions/others", middleware.JWTAuthMiddleware(), wrapper.WrapGinHandler(v1.DeleteOtherSessions))
+		group1.DELETE("/sessions/:id", middleware.JWTAuthMiddleware(), wrapper.WrapGinHandler(v1.DeleteSession))
 
 		{
 			userGroup := group1.Group("/users")
+			// 搜索用户（需要认证，并限制调用频率）
+			userGroup.GET(
+				"",
+				middleware.JWTAuthMiddleware(),
+				middleware.RateLimitMiddleware(searchRateLimit()),
+				wrapper.WrapGinHandler(v1.SearchUsers),
+			)
 			// 公开访问：获取单个用户详情
-			userGroup.GET("/:id", wrapper.WrapGinHandler(v1.GetUsers))
+			// 登录时额外返回与当前用户的好友关系
+			userGroup.GET("/:id", middleware.OptionalJWTAuthMiddleware(), wrapper.WrapGinHandler(v1.GetUsers))
 			// 私有访问：获取当前用户信息（需要认证）
 			userGroup.GET(
 				"/me",
@@ -80,7 +99,163 @@ func NewRouter() *gin.Engine {
 				middleware.JWTAuthMiddleware(),
 				wrapper.WrapGinHandler(v1.ModifyUsersMe),
 			)
+			// 重新发送验证邮件
+			userGroup.POST(
+				"/me/email/resend",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.ResendEmailVerification),
+			)
+			// 单聊消息
+			userGroup.POST(
+				"/:id/messages",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.SendDirectMessage),
+			)
+			userGroup.GET(
+				"/:id/messages",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.GetDirectMessages),
+			)
+			// 用户设置
+			userGroup.GET(
+				"/me/settings",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.GetUsersMeSettings),
+			)
+			userGroup.PATCH(
+				"/me/settings",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.ModifyUsersMeSettings),
+			)
+			// 两步验证
+			userGroup.GET(
+				"/me/2fa",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.GetUsersMeTwoFactor),
+			)
+			userGroup.POST(
+				"/me/2fa",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.EnrollTwoFactor),
+			)
+			userGroup.POST(
+				"/me/2fa/confirm",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.ConfirmTwoFactor),
+			)
+			userGroup.DELETE(
+				"/me/2fa",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.DisableTwoFactor),
+			)
+			// 我发起的入群申请
+			userGroup.GET(
+				"/me/join-requests",
+				middleware.JWTAuthMiddleware(),
+				wrapper.WrapGinHandler(v1.GetMyGroupJoinRequests),
+			)
+		}
+
+		{
+			friendRequestGroup := group1.Group("/friend-requests", middleware.JWTAuthMiddleware())
+			friendRequestGroup.POST("", wrapper.WrapGinHandler(v1.SendFriendRequest))
+			friendRequestGroup.GET("", wrapper.WrapGinHandler(v1.GetFriendRequests))
+			friendRequestGroup.POST("/:id/accept", wrapper.WrapGinHandler(v1.AcceptFriendRequest))
+			friendRequestGroup.POST("/:id/decline", wrapper.WrapGinHandler(v1.DeclineFriendRequest))
+			friendRequestGroup.DELETE("/:id", wrapper.WrapGinHandler(v1.CancelFriendRequest))
+
+			group1.GET("/friends", middleware.JWTAuthMiddleware(), wrapper.WrapGinHandler(v1.GetFriends))
+		}
+
+		{
+			blockGroup := group1.Group("/blocks", middleware.JWTAuthMiddleware())
+			blockGroup.GET("", wrapper.WrapGinHandler(v1.GetBlockedUsers))
+			blockGroup.PUT("/:id", wrapper.WrapGinHandler(v1.BlockUser))
+			blockGroup.DELETE("/:id", wrapper.WrapGinHandler(v1.UnblockUser))
+		}
+
+		{
+			// 联系人发现：按邮箱哈希匹配通讯录，限制调用频率防止遍历用户
+			discoveryGroup := group1.Group("/contact-discovery", middleware.JWTAuthMiddleware())
+			discoveryGroup.GET("", wrapper.WrapGinHandler(v1.GetDiscoveryConfig))
+			discoveryGroup.POST(
+				"",
+				middleware.RateLimitMiddleware(discoveryRateLimit()),
+				wrapper.WrapGinHandler(v1.DiscoverContacts),
+			)
+		}
+
+		{
+			groupGroup := group1.Group("/groups", middleware.JWTAuthMiddleware())
+			groupGroup.POST("", wrapper.WrapGinHandler(v1.CreateGroup))
+			groupGroup.GET("", wrapper.WrapGinHandler(v1.GetGroups))
+			groupGroup.GET("/:id", wrapper.WrapGinHandler(v1.GetGroup))
+			groupGroup.PATCH("/:id", wrapper.WrapGinHandler(v1.ModifyGroup))
+			// 群成员
+			groupGroup.GET("/:id/members", wrapper.WrapGinHandler(v1.GetGroupMembers))
+			groupGroup.POST("/:id/members", wrapper.WrapGinHandler(v1.AddGroupMembers))
+			groupGroup.DELETE("/:id/members/me", wrapper.WrapGinHandler(v1.LeaveGroup))
+			groupGroup.DELETE("/:id/members/:userId", wrapper.WrapGinHandler(v1.RemoveGroupMember))
+			groupGroup.PUT("/:id/members/:userId/role", wrapper.WrapGinHandler(v1.AssignGroupMemberRole))
+			// 群管理
+			groupGroup.PUT("/:id/members/:userId/mute", wrapper.WrapGinHandler(v1.MuteGroupMember))
+			groupGroup.DELETE("/:id/members/:userId/mute", wrapper.WrapGinHandler(v1.UnmuteGroupMember))
+			groupGroup.POST("/:id/bans", wrapper.WrapGinHandler(v1.BanGroupMember))
+			groupGroup.GET("/:id/bans", wrapper.WrapGinHandler(v1.GetGroupBans))
+			groupGroup.DELETE("/:id/bans/:userId", wrapper.WrapGinHandler(v1.UnbanGroupMember))
+			groupGroup.GET("/:id/moderation-logs", wrapper.WrapGinHandler(v1.GetGroupModerationLogs))
+			// 群角色
+			groupGroup.GET("/:id/roles", wrapper.WrapGinHandler(v1.GetGroupRoles))
+			groupGroup.POST("/:id/roles", wrapper.WrapGinHandler(v1.CreateGroupRole))
+			groupGroup.PATCH("/:id/roles/:role", wrapper.WrapGinHandler(v1.ModifyGroupRole))
+			groupGroup.DELETE("/:id/roles/:role", wrapper.WrapGinHandler(v1.DeleteGroupRole))
+			// 群聊消息
+			groupGroup.POST("/:id/messages", wrapper.WrapGinHandler(v1.SendGroupMessage))
+			groupGroup.GET("/:id/messages", wrapper.WrapGinHandler(v1.GetGroupMessages))
+			// 置顶消息
+			groupGroup.GET("/:id/pins", wrapper.WrapGinHandler(v1.GetGroupPins))
+			groupGroup.PUT("/:id/pins/:messageId", wrapper.WrapGinHandler(v1.PinGroupMessage))
+			groupGroup.DELETE("/:id/pins/:messageId", wrapper.WrapGinHandler(v1.UnpinGroupMessage))
+			// 邀请链接
+			groupGroup.POST("/:id/invites", wrapper.WrapGinHandler(v1.CreateGroupInvite))
+			groupGroup.GET("/:id/invites", wrapper.WrapGinHandler(v1.GetGroupInvites))
+			groupGroup.DELETE("/:id/invites/:inviteId", wrapper.WrapGinHandler(v1.RevokeGroupInvite))
+			// 入群申请
+			groupGroup.POST("/:id/join-requests", wrapper.WrapGinHandler(v1.CreateGroupJoinRequest))
+			groupGroup.GET("/:id/join-requests", wrapper.WrapGinHandler(v1.GetGroupJoinRequests))
+			groupGroup.POST("/:id/join-requests/review", wrapper.WrapGinHandler(v1.ReviewGroupJoinRequests))
+			groupGroup.POST("/:id/join-requests/:requestId/approve", wrapper.WrapGinHandler(v1.ApproveGroupJoinRequest))
+			groupGroup.POST("/:id/join-requests/:requestId/reject", wrapper.WrapGinHandler(v1.RejectGroupJoinRequest))
+		}
+
+		{
+			inviteGroup := group1.Group("/invites")
+			// 公开访问：加入前预览群信息
+			inviteGroup.GET("/:token", wrapper.WrapGinHandler(v1.PreviewGroupInvite))
+			inviteGroup.POST("/:token/join", middleware.JWTAuthMiddleware(), wrapper.WrapGinHandler(v1.JoinGroupByInvite))
+		}
+
+		{
+			conversationGroup := group1.Group("/conversations", middleware.JWTAuthMiddleware())
+			conversationGroup.GET("/:id/messages", wrapper.WrapGinHandler(v1.GetConversationMessages))
+			conversationGroup.POST("/:id/receipts", wrapper.WrapGinHandler(v1.AckReceipt))
 		}
+
+		{
+			messageGroup := group1.Group("/messages", middleware.JWTAuthMiddleware())
+			messageGroup.PATCH("/:id", wrapper.WrapGinHandler(v1.EditMessage))
+			messageGroup.DELETE("/:id", wrapper.WrapGinHandler(v1.DeleteMessage))
+			messageGroup.GET("/:id/revisions", wrapper.WrapGinHandler(v1.GetMessageRevisions))
+			messageGroup.GET("/:id/replies", wrapper.WrapGinHandler(v1.GetThreadReplies))
+			messageGroup.GET("/:id/receipts", wrapper.WrapGinHandler(v1.GetMessageReceipts))
+			// 表情回应
+			messageGroup.POST("/:id/reactions", wrapper.WrapGinHandler(v1.AddReaction))
+			messageGroup.DELETE("/:id/reactions/:emoji", wrapper.WrapGinHandler(v1.RemoveReaction))
+		}
+
+		// 实时通道：WebSocket 无法复用 WrapGinHandler，握手成功后由 Hub 接管连接
+		group1.GET("/ws", middleware.WSAuthMiddleware(), v1.ServeWS)
+		v1.RegisterWSHandlers()
 	}
 
 	// programatically set swagger info
@@ -93,6 +268,17 @@ func NewRouter() *gin.Engine {
 	return ginServer
 }
 
+// 未配置 rateLimit.search 时的默认值，单位: 次/分钟
+const defaultSearchRateLimit = 30
+
+func searchRateLimit() int {
+	limit := config.GetConfig().RateLimit.Search
+	if limit <= 0 {
+		return defaultSearchRateLimit
+	}
+	return limit
+}
+
 func enableCORS() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		method := ctx.Request.Method
@@ -132,3 +318,25 @@ func enableCORS() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// 未配置 rateLimit.discovery 时的默认值，单位: 次/分钟
+const defaultDiscoveryRateLimit = 5
+
+func discoveryRateLimit() int {
+	limit := config.GetConfig().RateLimit.Discovery
+	if limit <= 0 {
+		return defaultDiscoveryRateLimit
+	}
+	return limit
+}
+
+// 未配置 rateLimit.twoFactor 时的默认值，单位: 次/分钟
+const defaultTwoFactorRateLimit = 10
+
+func twoFactorRateLimit() int {
+	limit := config.GetConfig().RateLimit.TwoFactor
+	if limit <= 0 {
+		return defaultTwoFactorRateLimit
+	}
+	return limit
+}
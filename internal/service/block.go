@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type BlockService struct {
+}
+
+// Block 屏蔽用户，重复屏蔽时忽略
+// 屏蔽后对方不能发起单聊、发送好友申请、查看自己的在线状态和完整资料，双方的好友关系同时解除
+func (this *BlockService) Block(userUuid string, blockedUuid string) *common.ServiceError {
+	if userUuid == blockedUuid {
+		return common.ErrBlockSelf
+	}
+
+	user, err := repository.UserRepo.FindByUuid(blockedUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if user == nil {
+		return common.ErrUserNotFound
+	}
+
+	if _, err := repository.BlockRepo.Block(userUuid, blockedUuid, time.Now()); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo block user failed: %w", err))
+	}
+
+	return nil
+}
+
+// Unblock 取消屏蔽，未屏蔽时忽略
+func (this *BlockService) Unblock(userUuid string, blockedUuid string) *common.ServiceError {
+	if _, err := repository.BlockRepo.Unblock(userUuid, blockedUuid); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo unblock user failed: %w", err))
+	}
+
+	return nil
+}
+
+// ListBlocked 分页查询屏蔽名单
+func (this *BlockService) ListBlocked(
+	userUuid string,
+	req dto.PageRequest,
+) ([]dto.BlockedUserData, *common.PageMeta, *common.ServiceError) {
+	req.Normalize()
+
+	blocks, total, err := repository.BlockRepo.FindBlocked(userUuid, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find blocked users failed: %w", err))
+	}
+
+	blockedUuids := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		blockedUuids = append(blockedUuids, block.BlockedUuid)
+	}
+
+	users, err := repository.UserRepo.FindByUuids(blockedUuids)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find users by uuids failed: %w", err))
+	}
+
+	userMap := make(map[string]*model.User, len(users))
+	for i := range users {
+		userMap[users[i].Uuid] = &users[i]
+	}
+
+	avatars, svcErr := UserSvc.visibleAvatars(userUuid, users)
+
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	list := make([]dto.BlockedUserData, 0, len(blocks))
+	for _, block := range blocks {
+		blockedData := dto.BlockedUserData{
+			Uuid:    block.BlockedUuid,
+			BlockAt: block.CreatedAt,
+		}
+		if user, ok := userMap[block.BlockedUuid]; ok {
+			blockedData.Username = user.Username
+			blockedData.Nickname = user.Nickname
+			blockedData.Avatar = avatars[user.Uuid]
+		}
+		list = append(list, blockedData)
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// ensureNotBlocked 校验两个用户之间不存在任一方向的屏蔽
+func (this *BlockService) ensureNotBlocked(userUuid string, otherUuid string) *common.ServiceError {
+	blocked, err := repository.BlockRepo.IsBlockedEither(userUuid, otherUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo check block failed: %w", err))
+	}
+
+	if blocked {
+		return common.ErrBlocked
+	}
+
+	return nil
+}
+
+var BlockSvc = &BlockService{}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type ConversationService struct {
+}
+
+// findConversationForUser 查询会话，并校验用户是否为会话的参与者
+// 单聊会话要求用户是双方之一，群聊会话要求用户是群成员
+func (this *ConversationService) findConversationForUser(
+	conversationUuid string,
+	userUuid string,
+) (*model.Conversation, *common.ServiceError) {
+	conversation, err := repository.ConversationRepo.FindByUuid(conversationUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find conversation by uuid failed: %w", err))
+	}
+
+	if conversation == nil {
+		return nil, common.ErrConversationNotFound
+	}
+
+	switch conversation.Type {
+	case model.ConversationTypeDirect:
+		if !conversation.HasParticipant(userUuid) {
+			return nil, common.ErrNotConversationMember
+		}
+	case model.ConversationTypeGroup:
+		member, err := repository.GroupRepo.FindMember(conversation.GroupUuid, userUuid)
+
+		if err != nil {
+			return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group member failed: %w", err))
+		}
+
+		if member == nil {
+			return nil, common.ErrNotConversationMember
+		}
+	}
+
+	return conversation, nil
+}
+
+// participantUuids 查询会话的所有参与者，用于实时事件推送
+func (this *ConversationService) participantUuids(conversation *model.Conversation) ([]string, error) {
+	if conversation.Type == model.ConversationTypeGroup {
+		return repository.GroupRepo.FindMemberUuids(conversation.GroupUuid)
+	}
+
+	return []string{conversation.UserAUuid, conversation.UserBUuid}, nil
+}
+
+var ConversationSvc = &ConversationService{}
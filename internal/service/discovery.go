@@ -0,0 +1,86 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// 联系人发现默认每次最多上传的哈希数
+const defaultDiscoveryBatchLimit = 500
+
+// DiscoveryService 通过邮箱哈希匹配通讯录中的联系人，服务端不接触明文邮箱
+type DiscoveryService struct {
+}
+
+func discoveryBatchLimit() int {
+	limit := config.GetConfig().Discovery.BatchLimit
+	if limit <= 0 {
+		return defaultDiscoveryBatchLimit
+	}
+	return limit
+}
+
+// emailHash 使用配置的盐计算邮箱哈希
+func emailHash(email string) string {
+	return model.HashEmail(config.GetConfig().Discovery.Salt, email)
+}
+
+func (this *DiscoveryService) GetConfig() *dto.DiscoveryConfigData {
+	return &dto.DiscoveryConfigData{
+		Algorithm:  "sha256",
+		Salt:       config.GetConfig().Discovery.Salt,
+		BatchLimit: discoveryBatchLimit(),
+	}
+}
+
+// Discover 返回与上传的哈希匹配、且允许被发现的用户
+func (this *DiscoveryService) Discover(
+	viewerUuid string,
+	req dto.DiscoverContactsRequest,
+) ([]dto.DiscoveredContactData, *common.ServiceError) {
+	if len(req.Hashes) > discoveryBatchLimit() {
+		return nil, common.ErrEmailHashesTooMany
+	}
+
+	hashes := make([]string, 0, len(req.Hashes))
+	for _, hash := range req.Hashes {
+		hash = strings.ToLower(hash)
+
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != 32 {
+			return nil, common.ErrEmailHashInvalid
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	slices.Sort(hashes)
+	hashes = slices.Compact(hashes)
+
+	users, err := repository.UserRepo.FindDiscoverableByEmailHashes(viewerUuid, hashes)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find users by email hashes failed: %w", err))
+	}
+
+	list := make([]dto.DiscoveredContactData, 0, len(users))
+	for _, user := range users {
+		list = append(list, dto.DiscoveredContactData{
+			Hash:     user.EmailHash,
+			Uuid:     user.Uuid,
+			Username: user.Username,
+			Nickname: user.Nickname,
+		})
+	}
+
+	return list, nil
+}
+
+var DiscoverySvc = &DiscoveryService{}
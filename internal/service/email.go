@@ -0,0 +1,230 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/mailer"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+const (
+	// 未配置 mail.verifyTokenTtl 时的默认值
+	defaultEmailVerifyTokenTtl = 24 * time.Hour
+	// 未配置 mail.resendCooldown 时的默认值
+	defaultEmailResendCooldown = time.Minute
+	// 定期清理过期验证记录的周期
+	emailVerificationPruneInterval = time.Hour
+)
+
+// EmailService 发送验证邮件并验证邮箱
+// 注册时填写的邮箱需要验证后才会标记为已验证，修改邮箱时新邮箱需要验证后才会替换当前邮箱
+type EmailService struct {
+	mailerOnce sync.Once
+	mailer     mailer.Mailer
+	mailerErr  error
+}
+
+// Resend 重新发送验证邮件：有待验证的新邮箱时发送到新邮箱，否则发送到尚未验证的当前邮箱
+func (this *EmailService) Resend(userUuid string) *common.ServiceError {
+	user, err := repository.UserRepo.FindByUuid(userUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if user == nil {
+		return common.ErrUserNotFound
+	}
+
+	email := user.PendingEmail
+	if email == "" && !user.EmailVerified {
+		email = user.Email
+	}
+
+	if email == "" {
+		return common.ErrEmailAlreadyVerified
+	}
+
+	if err := this.ensureCooldown(userUuid); err != nil {
+		return err
+	}
+
+	return this.sendVerification(user, email)
+}
+
+// Verify 使用验证邮件中的 Token 验证邮箱，每个 Token 只能使用一次
+func (this *EmailService) Verify(req dto.VerifyEmailRequest) (*dto.VerifyEmailData, *common.ServiceError) {
+	claims, err := common.ValidateEmailVerificationToken(req.Token)
+
+	if err != nil {
+		return nil, common.ErrEmailVerificationInvalid
+	}
+
+	verification, err := repository.EmailVerificationRepo.FindByUuid(claims.ID)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find email verification failed: %w", err))
+	}
+
+	if verification == nil ||
+		!verification.IsUsable() ||
+		verification.UserUuid != claims.Subject ||
+		verification.Email != claims.Email {
+		return nil, common.ErrEmailVerificationInvalid
+	}
+
+	user, err := repository.UserRepo.FindByUuid(verification.UserUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if user == nil {
+		return nil, common.ErrEmailVerificationInvalid
+	}
+
+	updates := map[string]any{"email_verified": true}
+
+	switch {
+	case user.PendingEmail != "" && verification.Email == user.PendingEmail:
+		// 新邮箱验证通过后才替换当前邮箱
+		updates["email"] = user.PendingEmail
+		updates["email_hash"] = emailHash(user.PendingEmail)
+		updates["pending_email"] = ""
+	case verification.Email == user.Email:
+		if user.EmailVerified {
+			return nil, common.ErrEmailAlreadyVerified
+		}
+		// 邮箱验证通过后才写入哈希，避免他人注册时冒用邮箱被联系人发现
+		updates["email_hash"] = emailHash(user.Email)
+	default:
+		// 邮箱已再次修改，旧的验证邮件随之失效
+		return nil, common.ErrEmailVerificationInvalid
+	}
+
+	applied, err := repository.EmailVerificationRepo.Apply(verification, updates)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo apply email verification failed: %w", err))
+	}
+
+	if !applied {
+		return nil, common.ErrEmailVerificationInvalid
+	}
+
+	return &dto.VerifyEmailData{Email: verification.Email}, nil
+}
+
+// ensureCooldown 校验距离上次发送验证邮件已超过冷却时间
+func (this *EmailService) ensureCooldown(userUuid string) *common.ServiceError {
+	latest, err := repository.EmailVerificationRepo.FindLatestByUser(userUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find latest email verification failed: %w", err))
+	}
+
+	if latest != nil && time.Since(latest.CreatedAt) < emailResendCooldown() {
+		return common.ErrEmailVerificationCooldown
+	}
+
+	return nil
+}
+
+// sendVerification 创建验证记录并发送验证邮件
+func (this *EmailService) sendVerification(user *model.User, email string) *common.ServiceError {
+	ttl := emailVerifyTokenTtl()
+	verification := &model.EmailVerification{
+		Uuid:      uuid.NewString(),
+		UserUuid:  user.Uuid,
+		Email:     email,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	token, err := common.GenerateEmailVerificationToken(verification.Uuid, user.Uuid, email, ttl)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate email verification token failed: %w", err))
+	}
+
+	if err := repository.EmailVerificationRepo.Create(verification); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create email verification failed: %w", err))
+	}
+
+	sender, err := this.getMailer()
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrEmailSendFailed, fmt.Errorf("create mailer failed: %w", err))
+	}
+
+	msg := mailer.Message{
+		To:      email,
+		Subject: "验证你的邮箱",
+		Body: fmt.Sprintf(
+			"%s，你好：\n\n请在 %d 小时内打开以下链接验证你的邮箱：\n\n%s%s\n\n如果这不是你本人的操作，请忽略这封邮件。\n",
+			user.Username,
+			int(ttl.Hours()),
+			config.GetConfig().Mail.VerifyUrl,
+			token,
+		),
+	}
+
+	if err := sender.Send(msg); err != nil {
+		return common.WrapServiceError(common.ErrEmailSendFailed, fmt.Errorf("send verification email failed: %w", err))
+	}
+
+	return nil
+}
+
+// sendVerificationQuietly 发送验证邮件，失败时只记录日志，用户可以稍后重新发送
+func (this *EmailService) sendVerificationQuietly(user *model.User, email string) {
+	if err := this.sendVerification(user, email); err != nil {
+		log.Logger.Error("发送验证邮件失败", log.String("userUuid", user.Uuid), log.Any("err", err))
+	}
+}
+
+// runPruner 定期删除已过期的验证记录
+func (this *EmailService) runPruner() {
+	ticker := time.NewTicker(emailVerificationPruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := repository.EmailVerificationRepo.DeleteExpired(time.Now()); err != nil {
+			log.Logger.Error("清理过期邮箱验证记录失败", log.Any("err", err))
+		}
+	}
+}
+
+// getMailer 按 mail.driver 配置创建 Mailer
+func (this *EmailService) getMailer() (mailer.Mailer, error) {
+	this.mailerOnce.Do(func() {
+		this.mailer, this.mailerErr = mailer.New(config.GetConfig().Mail)
+	})
+
+	return this.mailer, this.mailerErr
+}
+
+func emailVerifyTokenTtl() time.Duration {
+	hours := config.GetConfig().Mail.VerifyTokenTtl
+	if hours <= 0 {
+		return defaultEmailVerifyTokenTtl
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func emailResendCooldown() time.Duration {
+	seconds := config.GetConfig().Mail.ResendCooldown
+	if seconds <= 0 {
+		return defaultEmailResendCooldown
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+var EmailSvc = &EmailService{}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+const (
+	// 未配置 friend.requestTtl 时的默认值
+	defaultFriendRequestTtl = 7 * 24 * time.Hour
+	// 将过期申请标记为 expired 的周期
+	friendRequestExpireInterval = time.Hour
+)
+
+type FriendService struct {
+}
+
+// SendRequest 发送好友申请
+// 如果对方已经向自己发送了待处理的申请，则直接接受对方的申请
+func (this *FriendService) SendRequest(
+	userUuid string,
+	req dto.SendFriendRequestRequest,
+) (*dto.FriendRequestData, *common.ServiceError) {
+	if userUuid == req.ReceiverUuid {
+		return nil, common.ErrFriendRequestSelf
+	}
+
+	receiver, err := repository.UserRepo.FindByUuid(req.ReceiverUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if receiver == nil {
+		return nil, common.ErrReceiverNotFound
+	}
+
+	if err := BlockSvc.ensureNotBlocked(userUuid, req.ReceiverUuid); err != nil {
+		return nil, err
+	}
+
+	isFriend, err := repository.FriendRepo.IsFriend(userUuid, req.ReceiverUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo check friendship failed: %w", err))
+	}
+
+	if isFriend {
+		return nil, common.ErrAlreadyFriends
+	}
+
+	pending, err := repository.FriendRepo.FindPendingRequest(userUuid, req.ReceiverUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find pending friend request failed: %w", err))
+	}
+
+	if pending != nil {
+		return nil, common.ErrFriendRequestPending
+	}
+
+	reverse, err := repository.FriendRepo.FindPendingRequest(req.ReceiverUuid, userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find pending friend request failed: %w", err))
+	}
+
+	if reverse != nil {
+		return this.AcceptRequest(userUuid, reverse.Uuid)
+	}
+
+	request := &model.FriendRequest{
+		RequesterUuid: userUuid,
+		AddresseeUuid: req.ReceiverUuid,
+		Message:       req.Message,
+		Status:        model.FriendRequestPending,
+		ExpiresAt:     time.Now().Add(friendRequestTtl()),
+	}
+
+	if err := repository.FriendRepo.CreateRequest(request); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create friend request failed: %w", err))
+	}
+
+	requestData := toFriendRequestData(request)
+
+	ws.ClientHub.SendToUser(request.AddresseeUuid, ws.Event{Type: ws.EventFriendRequestReceived, Data: requestData})
+
+	return requestData, nil
+}
+
+// AcceptRequest 接受好友申请，仅被申请人可以操作
+func (this *FriendService) AcceptRequest(userUuid string, requestUuid string) (*dto.FriendRequestData, *common.ServiceError) {
+	request, serviceErr := this.findPendingRequest(requestUuid, userUuid, false)
+
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if serviceErr := BlockSvc.ensureNotBlocked(request.RequesterUuid, request.AddresseeUuid); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	now := time.Now()
+	accepted, err := repository.FriendRepo.AcceptRequest(request, now)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo accept friend request failed: %w", err))
+	}
+
+	if !accepted {
+		return nil, common.ErrFriendRequestNotPending
+	}
+
+	return this.responded(request, model.FriendRequestAccepted, now), nil
+}
+
+// DeclineRequest 拒绝好友申请，仅被申请人可以操作
+func (this *FriendService) DeclineRequest(userUuid string, requestUuid string) (*dto.FriendRequestData, *common.ServiceError) {
+	return this.updateStatus(userUuid, requestUuid, model.FriendRequestDeclined)
+}
+
+// CancelRequest 撤销自己发出的好友申请
+func (this *FriendService) CancelRequest(userUuid string, requestUuid string) (*dto.FriendRequestData, *common.ServiceError) {
+	return this.updateStatus(userUuid, requestUuid, model.FriendRequestCanceled)
+}
+
+// ListRequests 分页查询待处理的好友申请
+func (this *FriendService) ListRequests(
+	userUuid string,
+	req dto.ListFriendRequestsRequest,
+) ([]dto.FriendRequestData, *common.PageMeta, *common.ServiceError) {
+	req.Normalize()
+
+	requests, total, err := repository.FriendRepo.FindPendingRequests(
+		userUuid,
+		req.Direction != "outgoing",
+		req.Offset(),
+		req.PageSize,
+	)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find friend requests failed: %w", err))
+	}
+
+	list := make([]dto.FriendRequestData, 0, len(requests))
+	for i := range requests {
+		list = append(list, *toFriendRequestData(&requests[i]))
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// ListFriends 分页查询好友列表
+func (this *FriendService) ListFriends(
+	userUuid string,
+	req dto.PageRequest,
+) ([]dto.FriendData, *common.PageMeta, *common.ServiceError) {
+	req.Normalize()
+
+	friendships, total, err := repository.FriendRepo.FindFriends(userUuid, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find friends failed: %w", err))
+	}
+
+	friendUuids := make([]string, 0, len(friendships))
+	for _, friendship := range friendships {
+		friendUuids = append(friendUuids, friendship.FriendUuid)
+	}
+
+	users, err := repository.UserRepo.FindByUuids(friendUuids)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find users by uuids failed: %w", err))
+	}
+
+	userMap := make(map[string]*model.User, len(users))
+	for i := range users {
+		userMap[users[i].Uuid] = &users[i]
+	}
+
+	avatars, svcErr := UserSvc.visibleAvatars(userUuid, users)
+
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	list := make([]dto.FriendData, 0, len(friendships))
+	for _, friendship := range friendships {
+		friendData := dto.FriendData{
+			Uuid:     friendship.FriendUuid,
+			Presence: PresenceSvc.State(friendship.FriendUuid),
+			FriendAt: friendship.CreatedAt,
+		}
+		if user, ok := userMap[friendship.FriendUuid]; ok {
+			friendData.Username = user.Username
+			friendData.Nickname = user.Nickname
+			friendData.Avatar = avatars[user.Uuid]
+		}
+		list = append(list, friendData)
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// Friendship 查询 viewerUuid 与 userUuid 之间的好友关系
+func (this *FriendService) Friendship(viewerUuid string, userUuid string) (string, error) {
+	if viewerUuid == userUuid {
+		return model.FriendshipSelf, nil
+	}
+
+	blocked, err := repository.BlockRepo.IsBlocked(viewerUuid, userUuid)
+
+	if err != nil {
+		return "", err
+	}
+
+	if blocked {
+		return model.FriendshipBlocked, nil
+	}
+
+	isFriend, err := repository.FriendRepo.IsFriend(viewerUuid, userUuid)
+
+	if err != nil {
+		return "", err
+	}
+
+	if isFriend {
+		return model.FriendshipFriends, nil
+	}
+
+	sent, err := repository.FriendRepo.FindPendingRequest(viewerUuid, userUuid)
+
+	if err != nil {
+		return "", err
+	}
+
+	if sent != nil {
+		return model.FriendshipRequestSent, nil
+	}
+
+	received, err := repository.FriendRepo.FindPendingRequest(userUuid, viewerUuid)
+
+	if err != nil {
+		return "", err
+	}
+
+	if received != nil {
+		return model.FriendshipRequestReceived, nil
+	}
+
+	return model.FriendshipNone, nil
+}
+
+// updateStatus 拒绝或撤销好友申请
+func (this *FriendService) updateStatus(
+	userUuid string,
+	requestUuid string,
+	status string,
+) (*dto.FriendRequestData, *common.ServiceError) {
+	request, serviceErr := this.findPendingRequest(requestUuid, userUuid, status == model.FriendRequestCanceled)
+
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	now := time.Now()
+	updated, err := repository.FriendRepo.UpdateRequestStatus(request.Uuid, status, now)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo update friend request status failed: %w", err))
+	}
+
+	if !updated {
+		return nil, common.ErrFriendRequestNotPending
+	}
+
+	return this.responded(request, status, now), nil
+}
+
+// findPendingRequest 查询待处理的好友申请，并校验操作人
+// byRequester 为 true 时要求操作人为申请人，否则要求为被申请人
+func (this *FriendService) findPendingRequest(
+	requestUuid string,
+	userUuid string,
+	byRequester bool,
+) (*model.FriendRequest, *common.ServiceError) {
+	request, err := repository.FriendRepo.FindRequestByUuid(requestUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find friend request by uuid failed: %w", err))
+	}
+
+	// 与申请无关的用户看不到该申请
+	if request == nil || (request.RequesterUuid != userUuid && request.AddresseeUuid != userUuid) {
+		return nil, common.ErrFriendRequestNotFound
+	}
+
+	operatorUuid := request.AddresseeUuid
+	if byRequester {
+		operatorUuid = request.RequesterUuid
+	}
+
+	if userUuid != operatorUuid {
+		return nil, common.ErrFriendRequestPermissionDenied
+	}
+
+	if !request.IsPending() {
+		return nil, common.ErrFriendRequestNotPending
+	}
+
+	return request, nil
+}
+
+// responded 更新申请的处理结果，并通知申请双方
+func (this *FriendService) responded(request *model.FriendRequest, status string, at time.Time) *dto.FriendRequestData {
+	request.Status = status
+	request.RespondedAt = &at
+
+	requestData := toFriendRequestData(request)
+
+	ws.ClientHub.SendToUsers(
+		[]string{request.RequesterUuid, request.AddresseeUuid},
+		ws.Event{Type: ws.EventFriendRequestUpdated, Data: requestData},
+	)
+
+	return requestData
+}
+
+// runExpirer 定期将过期的好友申请标记为 expired
+// 查询时已按过期时间过滤，这里只是为了让数据库中的状态保持准确
+func (this *FriendService) runExpirer() {
+	ticker := time.NewTicker(friendRequestExpireInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := repository.FriendRepo.ExpireRequests(time.Now()); err != nil {
+			log.Logger.Error("标记过期好友申请失败", log.Any("err", err))
+		}
+	}
+}
+
+func toFriendRequestData(request *model.FriendRequest) *dto.FriendRequestData {
+	requestData := &dto.FriendRequestData{
+		Uuid:          request.Uuid,
+		RequesterUuid: request.RequesterUuid,
+		AddresseeUuid: request.AddresseeUuid,
+		Message:       request.Message,
+		Status:        request.Status,
+		ExpiresAt:     request.ExpiresAt,
+		RespondedAt:   request.RespondedAt,
+		CreateAt:      request.CreatedAt,
+	}
+
+	if request.Status == model.FriendRequestPending && !request.IsPending() {
+		requestData.Status = model.FriendRequestExpired
+	}
+
+	return requestData
+}
+
+func friendRequestTtl() time.Duration {
+	days := config.GetConfig().Friend.RequestTtl
+	if days <= 0 {
+		return defaultFriendRequestTtl
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+var FriendSvc = &FriendService{}
@@ -0,0 +1,462 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+type GroupService struct {
+}
+
+// CreateGroup 创建群聊，创建者自动成为群主
+func (this *GroupService) CreateGroup(
+	ownerUuid string,
+	req dto.CreateGroupRequest,
+) (*dto.GroupData, *common.ServiceError) {
+	memberUuids := uniqueUuids(req.MemberUuids, ownerUuid)
+
+	if err := ensureUsersExist(memberUuids); err != nil {
+		return nil, err
+	}
+
+	members := []model.GroupMember{{UserUuid: ownerUuid, Role: model.GroupRoleOwner}}
+	for _, memberUuid := range memberUuids {
+		members = append(members, model.GroupMember{UserUuid: memberUuid, Role: model.GroupRoleMember})
+	}
+
+	group := &model.Group{
+		Name:      req.Name,
+		Avatar:    req.Avatar,
+		OwnerUuid: ownerUuid,
+		Private:   req.Private,
+	}
+
+	if err := repository.GroupRepo.CreateGroup(group, members); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create group failed: %w", err))
+	}
+
+	groupData := toGroupData(group)
+
+	ws.ClientHub.SendToUsers(
+		append(memberUuids, ownerUuid),
+		ws.Event{Type: ws.EventGroupCreated, Data: groupData},
+	)
+
+	return groupData, nil
+}
+
+// GetGroup 获取群资料，仅群成员可见
+func (this *GroupService) GetGroup(userUuid string, groupUuid string) (*dto.GroupData, *common.ServiceError) {
+	group, _, err := this.findGroupAndMember(groupUuid, userUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toGroupData(group), nil
+}
+
+// ListMyGroups 分页查询当前用户加入的群
+func (this *GroupService) ListMyGroups(
+	userUuid string,
+	req dto.PageRequest,
+) ([]dto.GroupData, *common.PageMeta, *common.ServiceError) {
+	req.Normalize()
+
+	groups, total, err := repository.GroupRepo.FindByMember(userUuid, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find groups by member failed: %w", err))
+	}
+
+	list := make([]dto.GroupData, 0, len(groups))
+	for i := range groups {
+		list = append(list, *toGroupData(&groups[i]))
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// ModifyGroup 修改群名称、群头像或是否私有，需要 edit_group 权限
+func (this *GroupService) ModifyGroup(
+	operatorUuid string,
+	groupUuid string,
+	req dto.ModifyGroupRequest,
+) (*dto.GroupData, *common.ServiceError) {
+	if _, _, err := this.authorize(groupUuid, operatorUuid, model.GroupPermEditGroup); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]any{}
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Avatar != "" {
+		updates["avatar"] = req.Avatar
+	}
+	if req.Private != nil {
+		updates["private"] = *req.Private
+	}
+
+	group, repoErr := repository.GroupRepo.UpdatesByUuid(groupUuid, updates)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo updates group failed: %w", repoErr))
+	}
+
+	if group == nil {
+		return nil, common.ErrGroupNotFound
+	}
+
+	groupData := toGroupData(group)
+	this.broadcast(groupUuid, ws.Event{Type: ws.EventGroupUpdated, Data: groupData})
+
+	return groupData, nil
+}
+
+// AddMembers 添加群成员，需要 invite_members 权限，已在群内的用户会被忽略，不能添加被禁止加入的用户
+func (this *GroupService) AddMembers(
+	operatorUuid string,
+	groupUuid string,
+	req dto.AddGroupMembersRequest,
+) ([]string, *common.ServiceError) {
+	if _, _, err := this.authorize(groupUuid, operatorUuid, model.GroupPermInviteMembers); err != nil {
+		return nil, err
+	}
+
+	memberUuids := uniqueUuids(req.MemberUuids, operatorUuid)
+
+	if err := ensureUsersExist(memberUuids); err != nil {
+		return nil, err
+	}
+
+	if err := GroupModerationSvc.ensureNotBanned(groupUuid, memberUuids); err != nil {
+		return nil, err
+	}
+
+	added, repoErr := repository.GroupRepo.AddMembers(groupUuid, memberUuids)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo add group members failed: %w", repoErr))
+	}
+
+	if len(added) > 0 {
+		this.broadcast(groupUuid, ws.Event{
+			Type: ws.EventGroupMembersAdded,
+			Data: dto.GroupMembersChangedData{GroupUuid: groupUuid, OperatorUuid: operatorUuid, MemberUuids: added},
+		})
+	}
+
+	return added, nil
+}
+
+// RemoveMember 将成员移出群聊，需要 remove_members 权限，并且只能移除角色等级更低的成员
+func (this *GroupService) RemoveMember(
+	operatorUuid string,
+	groupUuid string,
+	memberUuid string,
+	reason string,
+) *common.ServiceError {
+	if err := validateModerationReason(reason); err != nil {
+		return err
+	}
+
+	group, err := GroupModerationSvc.authorizeTarget(groupUuid, operatorUuid, memberUuid, model.GroupPermRemoveMembers)
+
+	if err != nil {
+		return err
+	}
+
+	if err := this.removeMember(groupUuid, memberUuid, operatorUuid); err != nil {
+		return err
+	}
+
+	GroupModerationSvc.record(group, model.GroupModerationLog{
+		Action:       model.GroupModerationKick,
+		TargetUuid:   memberUuid,
+		OperatorUuid: operatorUuid,
+		Reason:       reason,
+	})
+
+	return nil
+}
+
+// LeaveGroup 退出群聊，群主不能退出
+func (this *GroupService) LeaveGroup(userUuid string, groupUuid string) *common.ServiceError {
+	_, member, err := this.findGroupAndMember(groupUuid, userUuid)
+
+	if err != nil {
+		return err
+	}
+
+	if member.Role == model.GroupRoleOwner {
+		return common.ErrGroupOwnerCannotLeave
+	}
+
+	return this.removeMember(groupUuid, userUuid, userUuid)
+}
+
+// ListMembers 分页查询群成员，仅群成员可见
+func (this *GroupService) ListMembers(
+	userUuid string,
+	groupUuid string,
+	req dto.PageRequest,
+) ([]dto.GroupMemberData, *common.PageMeta, *common.ServiceError) {
+	if _, _, err := this.findGroupAndMember(groupUuid, userUuid); err != nil {
+		return nil, nil, err
+	}
+
+	req.Normalize()
+
+	members, total, err := repository.GroupRepo.FindMembers(groupUuid, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group members failed: %w", err))
+	}
+
+	customRoles, err := repository.GroupRoleRepo.FindByGroup(groupUuid)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group roles failed: %w", err))
+	}
+
+	customPerms := make(map[string]model.GroupPermission, len(customRoles))
+	for _, role := range customRoles {
+		customPerms[role.Name] = role.Permissions
+	}
+
+	memberUuids := make([]string, 0, len(members))
+	for _, member := range members {
+		memberUuids = append(memberUuids, member.UserUuid)
+	}
+
+	users, err := repository.UserRepo.FindByUuids(memberUuids)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find users by uuids failed: %w", err))
+	}
+
+	userMap := make(map[string]*model.User, len(users))
+	for i := range users {
+		userMap[users[i].Uuid] = &users[i]
+	}
+
+	avatars, svcErr := UserSvc.visibleAvatars(userUuid, users)
+
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	list := make([]dto.GroupMemberData, 0, len(members))
+	for _, member := range members {
+		perms, ok := model.BuiltinGroupRolePermissions(member.Role)
+		if !ok {
+			perms = customPerms[member.Role]
+		}
+		memberData := dto.GroupMemberData{
+			Uuid:        member.UserUuid,
+			Role:        member.Role,
+			Permissions: perms.Names(),
+			JoinAt:      member.CreatedAt,
+		}
+		if member.IsMuted() {
+			memberData.MutedUntil = member.MutedUntil
+		}
+		if user, ok := userMap[member.UserUuid]; ok {
+			memberData.Username = user.Username
+			memberData.Nickname = user.Nickname
+			memberData.Avatar = avatars[user.Uuid]
+		}
+		list = append(list, memberData)
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// findGroupAndMember 查询群以及用户在群内的成员记录，用户不在群内时返回错误
+func (this *GroupService) findGroupAndMember(
+	groupUuid string,
+	userUuid string,
+) (*model.Group, *model.GroupMember, *common.ServiceError) {
+	group, err := repository.GroupRepo.FindByUuid(groupUuid)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group by uuid failed: %w", err))
+	}
+
+	if group == nil {
+		return nil, nil, common.ErrGroupNotFound
+	}
+
+	member, err := repository.GroupRepo.FindMember(groupUuid, userUuid)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group member failed: %w", err))
+	}
+
+	if member == nil {
+		return nil, nil, common.ErrNotGroupMember
+	}
+
+	return group, member, nil
+}
+
+// authorize 校验用户是群成员并拥有指定权限，所有需要权限的群接口都通过它鉴权
+func (this *GroupService) authorize(
+	groupUuid string,
+	userUuid string,
+	perm model.GroupPermission,
+) (*model.Group, *model.GroupMember, *common.ServiceError) {
+	group, member, err := this.findGroupAndMember(groupUuid, userUuid)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	perms, err := this.rolePermissions(groupUuid, member.Role)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !perms.Has(perm) {
+		return nil, nil, common.ErrGroupPermissionDenied
+	}
+
+	return group, member, nil
+}
+
+// rolePermissions 查询角色的权限，自定义角色已被删除时按普通成员处理
+func (this *GroupService) rolePermissions(groupUuid string, role string) (model.GroupPermission, *common.ServiceError) {
+	if perms, ok := model.BuiltinGroupRolePermissions(role); ok {
+		return perms, nil
+	}
+
+	customRole, err := repository.GroupRoleRepo.FindByName(groupUuid, role)
+
+	if err != nil {
+		return 0, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group role failed: %w", err))
+	}
+
+	if customRole == nil {
+		perms, _ := model.BuiltinGroupRolePermissions(model.GroupRoleMember)
+		return perms, nil
+	}
+
+	return customRole.Permissions, nil
+}
+
+// rolesWithPermission 查询群内拥有指定权限的所有角色，包括内置角色和自定义角色
+func (this *GroupService) rolesWithPermission(groupUuid string, perm model.GroupPermission) ([]string, error) {
+	roles := []string{}
+	for _, role := range []string{model.GroupRoleOwner, model.GroupRoleAdmin, model.GroupRoleModerator, model.GroupRoleMember} {
+		if perms, _ := model.BuiltinGroupRolePermissions(role); perms.Has(perm) {
+			roles = append(roles, role)
+		}
+	}
+
+	customRoles, err := repository.GroupRoleRepo.FindByGroup(groupUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range customRoles {
+		if role.Permissions.Has(perm) {
+			roles = append(roles, role.Name)
+		}
+	}
+
+	return roles, nil
+}
+
+func (this *GroupService) removeMember(groupUuid string, memberUuid string, operatorUuid string) *common.ServiceError {
+	removed, err := repository.GroupRepo.RemoveMember(groupUuid, memberUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo remove group member failed: %w", err))
+	}
+
+	if !removed {
+		return common.ErrGroupMemberNotFound
+	}
+
+	this.notifyMemberRemoved(groupUuid, memberUuid, operatorUuid)
+
+	return nil
+}
+
+// notifyMemberRemoved 通知群成员和被移除的用户
+func (this *GroupService) notifyMemberRemoved(groupUuid string, memberUuid string, operatorUuid string) {
+	event := ws.Event{
+		Type: ws.EventGroupMembersRemoved,
+		Data: dto.GroupMembersChangedData{GroupUuid: groupUuid, OperatorUuid: operatorUuid, MemberUuids: []string{memberUuid}},
+	}
+	this.broadcast(groupUuid, event)
+	// 被移除的用户已不在成员列表中，需要单独通知
+	ws.ClientHub.SendToUser(memberUuid, event)
+}
+
+// broadcast 将事件推送给群内所有成员
+func (this *GroupService) broadcast(groupUuid string, event ws.Event) {
+	memberUuids, err := repository.GroupRepo.FindMemberUuids(groupUuid)
+
+	if err != nil {
+		log.Logger.Error("查询群成员失败", log.String("groupUuid", groupUuid), log.Any("err", err))
+		return
+	}
+
+	ws.ClientHub.SendToUsers(memberUuids, event)
+}
+
+// ensureUsersExist 校验所有用户都存在
+func ensureUsersExist(uuids []string) *common.ServiceError {
+	users, err := repository.UserRepo.FindByUuids(uuids)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find users by uuids failed: %w", err))
+	}
+
+	if len(users) != len(uuids) {
+		return common.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// uniqueUuids 对 uuid 去重，并排除指定的 uuid
+func uniqueUuids(uuids []string, exclude string) []string {
+	seen := map[string]struct{}{exclude: {}}
+	result := []string{}
+
+	for _, uuid := range uuids {
+		if _, ok := seen[uuid]; ok {
+			continue
+		}
+		seen[uuid] = struct{}{}
+		result = append(result, uuid)
+	}
+
+	return result
+}
+
+func toGroupData(group *model.Group) *dto.GroupData {
+	return &dto.GroupData{
+		Uuid:             group.Uuid,
+		Name:             group.Name,
+		Avatar:           group.Avatar,
+		OwnerUuid:        group.OwnerUuid,
+		ConversationUuid: group.ConversationUuid,
+		MemberCount:      group.MemberCount,
+		Private:          group.Private,
+		CreateAt:         group.CreatedAt,
+	}
+}
+
+var GroupSvc = &GroupService{}
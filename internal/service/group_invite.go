@@ -0,0 +1,242 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// 入群结果
+const (
+	JoinStatusJoined  = "joined"
+	JoinStatusPending = "pending"
+)
+
+type GroupInviteService struct {
+}
+
+// CreateInvite 创建邀请链接，需要 invite_members 权限
+func (this *GroupInviteService) CreateInvite(
+	operatorUuid string,
+	groupUuid string,
+	req dto.CreateGroupInviteRequest,
+) (*dto.GroupInviteData, *common.ServiceError) {
+	if _, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermInviteMembers); err != nil {
+		return nil, err
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, common.ErrInviteExpiresAtInvalid
+	}
+
+	invite := &model.GroupInvite{
+		GroupUuid:       groupUuid,
+		CreatorUuid:     operatorUuid,
+		ExpiresAt:       req.ExpiresAt,
+		MaxUses:         req.MaxUses,
+		RequireApproval: req.RequireApproval,
+	}
+
+	if err := repository.GroupInviteRepo.Create(invite); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create group invite failed: %w", err))
+	}
+
+	return toGroupInviteData(invite), nil
+}
+
+// ListInvites 分页查询群内仍然可用的邀请链接，需要 invite_members 权限
+func (this *GroupInviteService) ListInvites(
+	operatorUuid string,
+	groupUuid string,
+	req dto.PageRequest,
+) ([]dto.GroupInviteData, *common.PageMeta, *common.ServiceError) {
+	if _, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermInviteMembers); err != nil {
+		return nil, nil, err
+	}
+
+	req.Normalize()
+
+	invites, total, err := repository.GroupInviteRepo.FindActive(groupUuid, time.Now(), req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find active group invites failed: %w", err))
+	}
+
+	list := make([]dto.GroupInviteData, 0, len(invites))
+	for i := range invites {
+		list = append(list, *toGroupInviteData(&invites[i]))
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// RevokeInvite 撤销邀请链接，需要 invite_members 权限
+func (this *GroupInviteService) RevokeInvite(operatorUuid string, groupUuid string, inviteUuid string) *common.ServiceError {
+	if _, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermInviteMembers); err != nil {
+		return err
+	}
+
+	invite, err := repository.GroupInviteRepo.FindByUuid(inviteUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group invite failed: %w", err))
+	}
+
+	if invite == nil || invite.GroupUuid != groupUuid {
+		return common.ErrInviteNotFound
+	}
+
+	if err := repository.GroupInviteRepo.Revoke(inviteUuid, time.Now()); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo revoke group invite failed: %w", err))
+	}
+
+	return nil
+}
+
+// Preview 公开预览邀请链接对应的群信息
+func (this *GroupInviteService) Preview(token string) (*dto.GroupInvitePreviewData, *common.ServiceError) {
+	invite, group, err := this.findActiveInvite(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GroupInvitePreviewData{
+		GroupUuid:       group.Uuid,
+		Name:            group.Name,
+		Avatar:          group.Avatar,
+		MemberCount:     group.MemberCount,
+		RequireApproval: invite.RequireApproval,
+		ExpiresAt:       invite.ExpiresAt,
+	}, nil
+}
+
+// Join 通过邀请链接入群，链接需要审批时创建入群申请
+func (this *GroupInviteService) Join(userUuid string, token string) (*dto.JoinGroupData, *common.ServiceError) {
+	invite, group, err := this.findActiveInvite(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := GroupModerationSvc.ensureNotBanned(group.Uuid, []string{userUuid}); err != nil {
+		return nil, err
+	}
+
+	if invite.RequireApproval {
+		return this.requestToJoin(userUuid, invite)
+	}
+
+	member, repoErr := repository.GroupRepo.FindMember(group.Uuid, userUuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group member failed: %w", repoErr))
+	}
+
+	if member != nil {
+		return nil, common.ErrAlreadyGroupMember
+	}
+
+	available, joined, repoErr := repository.GroupInviteRepo.JoinByInvite(invite.Uuid, group.Uuid, userUuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo join group by invite failed: %w", repoErr))
+	}
+
+	if !available {
+		return nil, common.ErrInviteUnavailable
+	}
+
+	// 并发加入时可能已经通过其他请求成为群成员
+	if !joined {
+		return nil, common.ErrAlreadyGroupMember
+	}
+
+	GroupSvc.broadcast(group.Uuid, ws.Event{
+		Type: ws.EventGroupMembersAdded,
+		Data: dto.GroupMembersChangedData{GroupUuid: group.Uuid, OperatorUuid: userUuid, MemberUuids: []string{userUuid}},
+	})
+
+	group.MemberCount++
+
+	return &dto.JoinGroupData{Status: JoinStatusJoined, Group: toGroupData(group)}, nil
+}
+
+// requestToJoin 通过需要审批的邀请链接创建入群申请，并通知有审批权限的成员
+func (this *GroupInviteService) requestToJoin(userUuid string, invite *model.GroupInvite) (*dto.JoinGroupData, *common.ServiceError) {
+	if err := GroupJoinRequestSvc.ensureCanRequest(invite.GroupUuid, userUuid); err != nil {
+		return nil, err
+	}
+
+	request := &model.GroupJoinRequest{
+		GroupUuid:  invite.GroupUuid,
+		UserUuid:   userUuid,
+		InviteUuid: invite.Uuid,
+		Status:     model.GroupJoinRequestPending,
+	}
+
+	created, err := repository.GroupInviteRepo.RequestByInvite(invite.Uuid, request)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo request to join by invite failed: %w", err))
+	}
+
+	if !created {
+		return nil, common.ErrInviteUnavailable
+	}
+
+	requestData := toGroupJoinRequestData(request)
+	GroupJoinRequestSvc.notifyManagers(invite.GroupUuid, ws.Event{Type: ws.EventGroupJoinRequestReceived, Data: requestData})
+
+	return &dto.JoinGroupData{Status: JoinStatusPending, JoinRequest: requestData}, nil
+}
+
+// findActiveInvite 查询可用的邀请链接及其所属的群
+func (this *GroupInviteService) findActiveInvite(token string) (*model.GroupInvite, *model.Group, *common.ServiceError) {
+	invite, err := repository.GroupInviteRepo.FindByToken(token)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group invite by token failed: %w", err))
+	}
+
+	if invite == nil {
+		return nil, nil, common.ErrInviteNotFound
+	}
+
+	if !invite.IsActive() {
+		return nil, nil, common.ErrInviteUnavailable
+	}
+
+	group, err := repository.GroupRepo.FindByUuid(invite.GroupUuid)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group by uuid failed: %w", err))
+	}
+
+	if group == nil {
+		return nil, nil, common.ErrInviteNotFound
+	}
+
+	return invite, group, nil
+}
+
+func toGroupInviteData(invite *model.GroupInvite) *dto.GroupInviteData {
+	return &dto.GroupInviteData{
+		Uuid:            invite.Uuid,
+		Token:           invite.Token,
+		GroupUuid:       invite.GroupUuid,
+		CreatorUuid:     invite.CreatorUuid,
+		ExpiresAt:       invite.ExpiresAt,
+		MaxUses:         invite.MaxUses,
+		UseCount:        invite.UseCount,
+		RequireApproval: invite.RequireApproval,
+		CreateAt:        invite.CreatedAt,
+	}
+}
+
+var GroupInviteSvc = &GroupInviteService{}
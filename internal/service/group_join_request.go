@@ -0,0 +1,340 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+// 批量审批的操作
+const (
+	ReviewActionApprove = "approve"
+	ReviewActionReject  = "reject"
+)
+
+// 未配置 group.joinRequestCooldown 时的默认值，单位: 小时
+const defaultJoinRequestCooldown = 24
+
+type GroupJoinRequestService struct {
+}
+
+func joinRequestCooldown() time.Duration {
+	cooldown := config.GetConfig().Group.JoinRequestCooldown
+	if cooldown <= 0 {
+		cooldown = defaultJoinRequestCooldown
+	}
+	return time.Duration(cooldown) * time.Hour
+}
+
+// CreateRequest 申请加入私有群，并通知有审批权限的成员
+func (this *GroupJoinRequestService) CreateRequest(
+	userUuid string,
+	groupUuid string,
+	req dto.CreateGroupJoinRequestRequest,
+) (*dto.GroupJoinRequestData, *common.ServiceError) {
+	group, err := repository.GroupRepo.FindByUuid(groupUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group by uuid failed: %w", err))
+	}
+
+	if group == nil {
+		return nil, common.ErrGroupNotFound
+	}
+
+	if !group.Private {
+		return nil, common.ErrGroupNotPrivate
+	}
+
+	if err := this.ensureCanRequest(groupUuid, userUuid); err != nil {
+		return nil, err
+	}
+
+	request := &model.GroupJoinRequest{
+		GroupUuid: groupUuid,
+		UserUuid:  userUuid,
+		Message:   req.Message,
+		Status:    model.GroupJoinRequestPending,
+	}
+
+	if err := repository.GroupJoinRequestRepo.Create(request); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create join request failed: %w", err))
+	}
+
+	requestData := toGroupJoinRequestData(request)
+	this.notifyManagers(groupUuid, ws.Event{Type: ws.EventGroupJoinRequestReceived, Data: requestData})
+
+	return requestData, nil
+}
+
+// ListMine 分页查询当前用户发起的入群申请及其状态
+func (this *GroupJoinRequestService) ListMine(
+	userUuid string,
+	req dto.PageRequest,
+) ([]dto.GroupJoinRequestData, *common.PageMeta, *common.ServiceError) {
+	req.Normalize()
+
+	requests, total, err := repository.GroupJoinRequestRepo.FindByUser(userUuid, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find join requests by user failed: %w", err))
+	}
+
+	list := make([]dto.GroupJoinRequestData, 0, len(requests))
+	for i := range requests {
+		list = append(list, *toGroupJoinRequestData(&requests[i]))
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// ListPending 分页查询群内待审批的入群申请，需要 invite_members 权限
+func (this *GroupJoinRequestService) ListPending(
+	operatorUuid string,
+	groupUuid string,
+	req dto.PageRequest,
+) ([]dto.GroupJoinRequestData, *common.PageMeta, *common.ServiceError) {
+	if _, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermInviteMembers); err != nil {
+		return nil, nil, err
+	}
+
+	req.Normalize()
+
+	requests, total, err := repository.GroupJoinRequestRepo.FindPendingByGroup(groupUuid, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find pending join requests failed: %w", err))
+	}
+
+	list := make([]dto.GroupJoinRequestData, 0, len(requests))
+	for i := range requests {
+		list = append(list, *toGroupJoinRequestData(&requests[i]))
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// Approve 通过入群申请，申请人加入群聊
+func (this *GroupJoinRequestService) Approve(
+	operatorUuid string,
+	groupUuid string,
+	requestUuid string,
+) (*dto.GroupJoinRequestData, *common.ServiceError) {
+	return this.reviewOne(operatorUuid, groupUuid, requestUuid, true)
+}
+
+// Reject 拒绝入群申请，被拒绝的用户在冷却时间内不能再次申请
+func (this *GroupJoinRequestService) Reject(
+	operatorUuid string,
+	groupUuid string,
+	requestUuid string,
+) (*dto.GroupJoinRequestData, *common.ServiceError) {
+	return this.reviewOne(operatorUuid, groupUuid, requestUuid, false)
+}
+
+// Review 批量审批入群申请，返回实际处理的申请，不属于该群或已处理的申请会被忽略
+func (this *GroupJoinRequestService) Review(
+	operatorUuid string,
+	groupUuid string,
+	req dto.ReviewGroupJoinRequestsRequest,
+) ([]dto.GroupJoinRequestData, *common.ServiceError) {
+	if _, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermInviteMembers); err != nil {
+		return nil, err
+	}
+
+	requests, err := repository.GroupJoinRequestRepo.FindByUuids(uniqueUuids(req.RequestUuids, ""))
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find join requests by uuids failed: %w", err))
+	}
+
+	approve := req.Action == ReviewActionApprove
+	reviewed := []dto.GroupJoinRequestData{}
+	approvedUuids := []string{}
+
+	for i := range requests {
+		request := &requests[i]
+
+		if request.GroupUuid != groupUuid || request.Status != model.GroupJoinRequestPending {
+			continue
+		}
+
+		ok, err := this.review(operatorUuid, request, approve)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		reviewed = append(reviewed, *toGroupJoinRequestData(request))
+		if approve {
+			approvedUuids = append(approvedUuids, request.UserUuid)
+		}
+	}
+
+	if len(approvedUuids) > 0 {
+		GroupSvc.broadcast(groupUuid, ws.Event{
+			Type: ws.EventGroupMembersAdded,
+			Data: dto.GroupMembersChangedData{GroupUuid: groupUuid, OperatorUuid: operatorUuid, MemberUuids: approvedUuids},
+		})
+	}
+
+	return reviewed, nil
+}
+
+// reviewOne 校验操作人权限，并审批群内单条待审批的申请
+func (this *GroupJoinRequestService) reviewOne(
+	operatorUuid string,
+	groupUuid string,
+	requestUuid string,
+	approve bool,
+) (*dto.GroupJoinRequestData, *common.ServiceError) {
+	if _, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermInviteMembers); err != nil {
+		return nil, err
+	}
+
+	request, repoErr := repository.GroupJoinRequestRepo.FindByUuid(requestUuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find join request failed: %w", repoErr))
+	}
+
+	if request == nil || request.GroupUuid != groupUuid {
+		return nil, common.ErrJoinRequestNotFound
+	}
+
+	if request.Status != model.GroupJoinRequestPending {
+		return nil, common.ErrJoinRequestNotPending
+	}
+
+	ok, err := this.review(operatorUuid, request, approve)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, common.ErrJoinRequestNotPending
+	}
+
+	if approve {
+		GroupSvc.broadcast(groupUuid, ws.Event{
+			Type: ws.EventGroupMembersAdded,
+			Data: dto.GroupMembersChangedData{GroupUuid: groupUuid, OperatorUuid: operatorUuid, MemberUuids: []string{request.UserUuid}},
+		})
+	}
+
+	return toGroupJoinRequestData(request), nil
+}
+
+// review 通过或拒绝申请，并通知申请人和有审批权限的成员，申请已被处理时返回 false
+func (this *GroupJoinRequestService) review(
+	operatorUuid string,
+	request *model.GroupJoinRequest,
+	approve bool,
+) (bool, *common.ServiceError) {
+	var ok bool
+	var err error
+
+	if approve {
+		ok, err = repository.GroupJoinRequestRepo.Approve(request, operatorUuid)
+	} else {
+		ok, err = repository.GroupJoinRequestRepo.Reject(request, operatorUuid)
+	}
+
+	if err != nil {
+		return false, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo review join request failed: %w", err))
+	}
+
+	if ok {
+		event := ws.Event{Type: ws.EventGroupJoinRequestUpdated, Data: toGroupJoinRequestData(request)}
+		this.notifyManagers(request.GroupUuid, event)
+		ws.ClientHub.SendToUser(request.UserUuid, event)
+	}
+
+	return ok, nil
+}
+
+// ensureCanRequest 校验用户可以发起入群申请：没有被禁止加入、不在群内、没有待审批的申请，且不在被拒绝后的冷却时间内
+func (this *GroupJoinRequestService) ensureCanRequest(groupUuid string, userUuid string) *common.ServiceError {
+	if err := GroupModerationSvc.ensureNotBanned(groupUuid, []string{userUuid}); err != nil {
+		return err
+	}
+
+	member, err := repository.GroupRepo.FindMember(groupUuid, userUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group member failed: %w", err))
+	}
+
+	if member != nil {
+		return common.ErrAlreadyGroupMember
+	}
+
+	pending, err := repository.GroupJoinRequestRepo.FindPending(groupUuid, userUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find pending join request failed: %w", err))
+	}
+
+	if pending != nil {
+		return common.ErrJoinRequestPending
+	}
+
+	rejected, err := repository.GroupJoinRequestRepo.FindLatestRejected(groupUuid, userUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find rejected join request failed: %w", err))
+	}
+
+	if rejected != nil && rejected.ReviewedAt != nil && time.Since(*rejected.ReviewedAt) < joinRequestCooldown() {
+		return common.ErrJoinRequestCooldown
+	}
+
+	return nil
+}
+
+// notifyManagers 将入群申请相关的事件推送给拥有 invite_members 权限的成员
+func (this *GroupJoinRequestService) notifyManagers(groupUuid string, event ws.Event) {
+	roles, err := GroupSvc.rolesWithPermission(groupUuid, model.GroupPermInviteMembers)
+
+	if err != nil {
+		log.Logger.Error("查询群角色失败", log.String("groupUuid", groupUuid), log.Any("err", err))
+		return
+	}
+
+	managerUuids, err := repository.GroupRepo.FindMemberUuidsByRoles(groupUuid, roles)
+
+	if err != nil {
+		log.Logger.Error("查询审批成员失败", log.String("groupUuid", groupUuid), log.Any("err", err))
+		return
+	}
+
+	ws.ClientHub.SendToUsers(managerUuids, event)
+}
+
+func toGroupJoinRequestData(request *model.GroupJoinRequest) *dto.GroupJoinRequestData {
+	return &dto.GroupJoinRequestData{
+		Uuid:         request.Uuid,
+		GroupUuid:    request.GroupUuid,
+		UserUuid:     request.UserUuid,
+		InviteUuid:   request.InviteUuid,
+		Message:      request.Message,
+		Status:       request.Status,
+		ReviewerUuid: request.ReviewerUuid,
+		ReviewedAt:   request.ReviewedAt,
+		CreateAt:     request.CreatedAt,
+	}
+}
+
+var GroupJoinRequestSvc = &GroupJoinRequestService{}
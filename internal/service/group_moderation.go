@@ -0,0 +1,381 @@
+package service
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+const (
+	// 定期清除到期禁言的周期
+	muteExpireInterval = 30 * time.Second
+	// 管理操作原因的最大长度
+	maxModerationReasonLength = 200
+)
+
+// GroupModerationService 处理群管理操作：移出、禁止加入和禁言
+// 每次操作都会记录管理日志，并在群内生成一条系统消息
+type GroupModerationService struct {
+}
+
+// Ban 禁止用户加入群聊，需要 ban_members 权限，用户在群内时会被移出
+func (this *GroupModerationService) Ban(
+	operatorUuid string,
+	groupUuid string,
+	req dto.BanGroupMemberRequest,
+) (*dto.GroupBanData, *common.ServiceError) {
+	group, err := this.authorizeTarget(groupUuid, operatorUuid, req.UserUuid, model.GroupPermBanMembers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureUsersExist([]string{req.UserUuid}); err != nil {
+		return nil, err
+	}
+
+	ban := &model.GroupBan{
+		GroupUuid:    groupUuid,
+		UserUuid:     req.UserUuid,
+		OperatorUuid: operatorUuid,
+		Reason:       req.Reason,
+	}
+
+	banned, removed, repoErr := repository.GroupModerationRepo.Ban(ban)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo ban group member failed: %w", repoErr))
+	}
+
+	if !banned {
+		return nil, common.ErrGroupUserAlreadyBanned
+	}
+
+	if removed {
+		GroupSvc.notifyMemberRemoved(groupUuid, req.UserUuid, operatorUuid)
+	}
+
+	this.record(group, model.GroupModerationLog{
+		Action:       model.GroupModerationBan,
+		TargetUuid:   req.UserUuid,
+		OperatorUuid: operatorUuid,
+		Reason:       req.Reason,
+	})
+
+	return toGroupBanData(ban), nil
+}
+
+// Unban 解除禁止，需要 ban_members 权限
+func (this *GroupModerationService) Unban(operatorUuid string, groupUuid string, userUuid string) *common.ServiceError {
+	group, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermBanMembers)
+
+	if err != nil {
+		return err
+	}
+
+	unbanned, repoErr := repository.GroupModerationRepo.Unban(groupUuid, userUuid)
+
+	if repoErr != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo unban group member failed: %w", repoErr))
+	}
+
+	if !unbanned {
+		return common.ErrGroupBanNotFound
+	}
+
+	this.record(group, model.GroupModerationLog{
+		Action:       model.GroupModerationUnban,
+		TargetUuid:   userUuid,
+		OperatorUuid: operatorUuid,
+	})
+
+	return nil
+}
+
+// ListBans 分页查询群内被禁止的用户，需要 ban_members 权限
+func (this *GroupModerationService) ListBans(
+	operatorUuid string,
+	groupUuid string,
+	req dto.PageRequest,
+) ([]dto.GroupBanData, *common.PageMeta, *common.ServiceError) {
+	if _, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermBanMembers); err != nil {
+		return nil, nil, err
+	}
+
+	req.Normalize()
+
+	bans, total, err := repository.GroupModerationRepo.FindBans(groupUuid, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group bans failed: %w", err))
+	}
+
+	list := make([]dto.GroupBanData, 0, len(bans))
+	for i := range bans {
+		list = append(list, *toGroupBanData(&bans[i]))
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// Mute 禁言成员，需要 mute_members 权限，重复禁言会覆盖之前的禁言结束时间
+func (this *GroupModerationService) Mute(
+	operatorUuid string,
+	groupUuid string,
+	memberUuid string,
+	req dto.MuteGroupMemberRequest,
+) *common.ServiceError {
+	group, err := this.authorizeTarget(groupUuid, operatorUuid, memberUuid, model.GroupPermMuteMembers)
+
+	if err != nil {
+		return err
+	}
+
+	mutedUntil := time.Now().Add(time.Duration(req.Duration) * time.Second)
+
+	return this.updateMute(group, operatorUuid, memberUuid, &mutedUntil, req.Reason)
+}
+
+// Unmute 解除禁言，需要 mute_members 权限
+func (this *GroupModerationService) Unmute(operatorUuid string, groupUuid string, memberUuid string) *common.ServiceError {
+	group, err := this.authorizeTarget(groupUuid, operatorUuid, memberUuid, model.GroupPermMuteMembers)
+
+	if err != nil {
+		return err
+	}
+
+	return this.updateMute(group, operatorUuid, memberUuid, nil, "")
+}
+
+// ListLogs 分页查询群管理日志，需要 remove_members、ban_members 或 mute_members 中的任一权限
+func (this *GroupModerationService) ListLogs(
+	operatorUuid string,
+	groupUuid string,
+	req dto.PageRequest,
+) ([]dto.GroupModerationLogData, *common.PageMeta, *common.ServiceError) {
+	_, operator, err := GroupSvc.findGroupAndMember(groupUuid, operatorUuid)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	perms, err := GroupSvc.rolePermissions(groupUuid, operator.Role)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if perms&(model.GroupPermRemoveMembers|model.GroupPermBanMembers|model.GroupPermMuteMembers) == 0 {
+		return nil, nil, common.ErrGroupPermissionDenied
+	}
+
+	req.Normalize()
+
+	logs, total, repoErr := repository.GroupModerationRepo.FindLogs(groupUuid, req.Offset(), req.PageSize)
+
+	if repoErr != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find moderation logs failed: %w", repoErr))
+	}
+
+	list := make([]dto.GroupModerationLogData, 0, len(logs))
+	for _, moderationLog := range logs {
+		list = append(list, dto.GroupModerationLogData{
+			Action:       moderationLog.Action,
+			TargetUuid:   moderationLog.TargetUuid,
+			OperatorUuid: moderationLog.OperatorUuid,
+			Reason:       moderationLog.Reason,
+			MutedUntil:   moderationLog.MutedUntil,
+			CreateAt:     moderationLog.CreatedAt,
+		})
+	}
+
+	return list, &common.PageMeta{Total: total, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// authorizeTarget 校验操作人拥有指定权限，并且只能对角色等级更低的成员操作
+// 目标用户不在群内时不校验等级
+func (this *GroupModerationService) authorizeTarget(
+	groupUuid string,
+	operatorUuid string,
+	targetUuid string,
+	perm model.GroupPermission,
+) (*model.Group, *common.ServiceError) {
+	group, operator, err := GroupSvc.authorize(groupUuid, operatorUuid, perm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if operatorUuid == targetUuid {
+		return nil, common.ErrGroupPermissionDenied
+	}
+
+	target, repoErr := repository.GroupRepo.FindMember(groupUuid, targetUuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group member failed: %w", repoErr))
+	}
+
+	if target != nil && model.GroupRoleRank(target.Role) >= model.GroupRoleRank(operator.Role) {
+		return nil, common.ErrGroupPermissionDenied
+	}
+
+	return group, nil
+}
+
+// updateMute 设置或解除禁言，通知群成员并记录管理操作
+func (this *GroupModerationService) updateMute(
+	group *model.Group,
+	operatorUuid string,
+	memberUuid string,
+	mutedUntil *time.Time,
+	reason string,
+) *common.ServiceError {
+	updated, err := repository.GroupRepo.UpdateMemberMute(group.Uuid, memberUuid, mutedUntil)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo update group member mute failed: %w", err))
+	}
+
+	if !updated {
+		return common.ErrGroupMemberNotFound
+	}
+
+	action := model.GroupModerationUnmute
+	if mutedUntil != nil {
+		action = model.GroupModerationMute
+	}
+
+	GroupSvc.broadcast(group.Uuid, ws.Event{
+		Type: ws.EventGroupMemberMuted,
+		Data: dto.GroupMemberMutedData{
+			GroupUuid:    group.Uuid,
+			OperatorUuid: operatorUuid,
+			UserUuid:     memberUuid,
+			MutedUntil:   mutedUntil,
+		},
+	})
+
+	this.record(group, model.GroupModerationLog{
+		Action:       action,
+		TargetUuid:   memberUuid,
+		OperatorUuid: operatorUuid,
+		Reason:       reason,
+		MutedUntil:   mutedUntil,
+	})
+
+	return nil
+}
+
+// record 记录管理日志，并在群内发送系统消息
+// 管理操作已经生效，记录失败只输出日志
+func (this *GroupModerationService) record(group *model.Group, moderationLog model.GroupModerationLog) {
+	moderationLog.GroupUuid = group.Uuid
+
+	if err := repository.GroupModerationRepo.CreateLog(&moderationLog); err != nil {
+		log.Logger.Error("记录群管理日志失败", log.String("groupUuid", group.Uuid), log.Any("err", err))
+	}
+
+	content := dto.SystemMessageContent{
+		Action:       moderationLog.Action,
+		TargetUuid:   moderationLog.TargetUuid,
+		OperatorUuid: moderationLog.OperatorUuid,
+		Reason:       moderationLog.Reason,
+		MutedUntil:   moderationLog.MutedUntil,
+	}
+
+	if err := MessageSvc.sendSystemMessage(group, moderationLog.OperatorUuid, content); err != nil {
+		log.Logger.Error("发送群系统消息失败", log.String("groupUuid", group.Uuid), log.Any("err", err))
+	}
+}
+
+// runMuteExpirer 定期清除到期的禁言，并通知群成员
+func (this *GroupModerationService) runMuteExpirer() {
+	ticker := time.NewTicker(muteExpireInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		members, err := repository.GroupRepo.FindExpiredMutes(now)
+
+		if err != nil {
+			log.Logger.Error("查询到期禁言失败", log.Any("err", err))
+			continue
+		}
+
+		for i := range members {
+			this.expireMute(&members[i], now)
+		}
+	}
+}
+
+func (this *GroupModerationService) expireMute(member *model.GroupMember, now time.Time) {
+	cleared, err := repository.GroupRepo.ClearExpiredMute(member, now)
+
+	if err != nil {
+		log.Logger.Error("清除到期禁言失败", log.String("groupUuid", member.GroupUuid), log.Any("err", err))
+		return
+	}
+
+	if !cleared {
+		return
+	}
+
+	group, err := repository.GroupRepo.FindByUuid(member.GroupUuid)
+
+	if err != nil || group == nil {
+		log.Logger.Error("查询群失败", log.String("groupUuid", member.GroupUuid), log.Any("err", err))
+		return
+	}
+
+	GroupSvc.broadcast(group.Uuid, ws.Event{
+		Type: ws.EventGroupMemberMuted,
+		Data: dto.GroupMemberMutedData{GroupUuid: group.Uuid, UserUuid: member.UserUuid},
+	})
+
+	this.record(group, model.GroupModerationLog{
+		Action:     model.GroupModerationMuteExpired,
+		TargetUuid: member.UserUuid,
+	})
+}
+
+// ensureNotBanned 校验用户没有被禁止加入群聊
+func (this *GroupModerationService) ensureNotBanned(groupUuid string, userUuids []string) *common.ServiceError {
+	bannedUuids, err := repository.GroupModerationRepo.FindBannedUuids(groupUuid, userUuids)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find banned uuids failed: %w", err))
+	}
+
+	if len(bannedUuids) > 0 {
+		return common.ErrGroupUserBanned
+	}
+
+	return nil
+}
+
+// validateModerationReason 校验通过查询参数传入的原因
+func validateModerationReason(reason string) *common.ServiceError {
+	if utf8.RuneCountInString(reason) > maxModerationReasonLength {
+		return common.ErrModerationReasonInvalid
+	}
+	return nil
+}
+
+func toGroupBanData(ban *model.GroupBan) *dto.GroupBanData {
+	return &dto.GroupBanData{
+		UserUuid:     ban.UserUuid,
+		OperatorUuid: ban.OperatorUuid,
+		Reason:       ban.Reason,
+		CreateAt:     ban.CreatedAt,
+	}
+}
+
+var GroupModerationSvc = &GroupModerationService{}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"gorm.io/gorm"
+)
+
+// 内置角色的展示顺序
+var builtinGroupRoleNames = []string{
+	model.GroupRoleOwner,
+	model.GroupRoleAdmin,
+	model.GroupRoleModerator,
+	model.GroupRoleMember,
+}
+
+// GroupRoleService 管理群内的自定义角色以及成员角色，只有群主可以操作
+type GroupRoleService struct {
+}
+
+// ListRoles 查询群内的内置角色和自定义角色，仅群成员可见
+func (this *GroupRoleService) ListRoles(userUuid string, groupUuid string) ([]dto.GroupRoleData, *common.ServiceError) {
+	if _, _, err := GroupSvc.findGroupAndMember(groupUuid, userUuid); err != nil {
+		return nil, err
+	}
+
+	customRoles, err := repository.GroupRoleRepo.FindByGroup(groupUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group roles failed: %w", err))
+	}
+
+	list := make([]dto.GroupRoleData, 0, len(builtinGroupRoleNames)+len(customRoles))
+	for _, name := range builtinGroupRoleNames {
+		perms, _ := model.BuiltinGroupRolePermissions(name)
+		list = append(list, dto.GroupRoleData{Name: name, Builtin: true, Permissions: perms.Names()})
+	}
+	for _, role := range customRoles {
+		list = append(list, *toGroupRoleData(&role))
+	}
+
+	return list, nil
+}
+
+// CreateRole 创建自定义角色，名称不能与内置角色或已有角色重复
+func (this *GroupRoleService) CreateRole(
+	operatorUuid string,
+	groupUuid string,
+	req dto.CreateGroupRoleRequest,
+) (*dto.GroupRoleData, *common.ServiceError) {
+	if err := this.ensureOwner(groupUuid, operatorUuid); err != nil {
+		return nil, err
+	}
+
+	if _, ok := model.BuiltinGroupRolePermissions(req.Role); ok {
+		return nil, common.ErrGroupRoleReserved
+	}
+
+	perms, ok := model.ParseGroupPermissions(req.Permissions)
+
+	if !ok {
+		return nil, common.ErrGroupPermissionInvalid
+	}
+
+	role := &model.GroupRole{GroupUuid: groupUuid, Name: req.Role, Permissions: perms}
+
+	if err := repository.GroupRoleRepo.Create(role); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, common.ErrGroupRoleConflict
+		}
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create group role failed: %w", err))
+	}
+
+	return toGroupRoleData(role), nil
+}
+
+// ModifyRole 整体替换自定义角色的权限，内置角色不能修改
+func (this *GroupRoleService) ModifyRole(
+	operatorUuid string,
+	groupUuid string,
+	name string,
+	req dto.ModifyGroupRoleRequest,
+) (*dto.GroupRoleData, *common.ServiceError) {
+	role, err := this.findCustomRole(groupUuid, operatorUuid, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	perms, ok := model.ParseGroupPermissions(req.Permissions)
+
+	if !ok {
+		return nil, common.ErrGroupPermissionInvalid
+	}
+
+	if repoErr := repository.GroupRoleRepo.UpdatePermissions(role, perms); repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo update group role failed: %w", repoErr))
+	}
+
+	role.Permissions = perms
+
+	return toGroupRoleData(role), nil
+}
+
+// DeleteRole 删除自定义角色，拥有该角色的成员恢复为普通成员
+func (this *GroupRoleService) DeleteRole(operatorUuid string, groupUuid string, name string) *common.ServiceError {
+	role, err := this.findCustomRole(groupUuid, operatorUuid, name)
+
+	if err != nil {
+		return err
+	}
+
+	memberUuids, repoErr := repository.GroupRepo.FindMemberUuidsByRoles(groupUuid, []string{role.Name})
+
+	if repoErr != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group members by roles failed: %w", repoErr))
+	}
+
+	if repoErr := repository.GroupRoleRepo.Delete(role); repoErr != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo delete group role failed: %w", repoErr))
+	}
+
+	for _, memberUuid := range memberUuids {
+		this.notifyRoleChanged(groupUuid, operatorUuid, memberUuid, model.GroupRoleMember)
+	}
+
+	return nil
+}
+
+// AssignRole 修改成员的角色，不能修改群主的角色，也不能将成员设置为群主
+func (this *GroupRoleService) AssignRole(
+	operatorUuid string,
+	groupUuid string,
+	memberUuid string,
+	req dto.AssignGroupRoleRequest,
+) *common.ServiceError {
+	if err := this.ensureOwner(groupUuid, operatorUuid); err != nil {
+		return err
+	}
+
+	if req.Role == model.GroupRoleOwner || memberUuid == operatorUuid {
+		return common.ErrGroupPermissionDenied
+	}
+
+	if _, ok := model.BuiltinGroupRolePermissions(req.Role); !ok {
+		role, err := repository.GroupRoleRepo.FindByName(groupUuid, req.Role)
+
+		if err != nil {
+			return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group role failed: %w", err))
+		}
+
+		if role == nil {
+			return common.ErrGroupRoleNotFound
+		}
+	}
+
+	member, err := repository.GroupRepo.FindMember(groupUuid, memberUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group member failed: %w", err))
+	}
+
+	if member == nil {
+		return common.ErrGroupMemberNotFound
+	}
+
+	if member.Role == req.Role {
+		return nil
+	}
+
+	if err := repository.GroupRepo.UpdateMemberRole(groupUuid, memberUuid, req.Role); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo update group member role failed: %w", err))
+	}
+
+	this.notifyRoleChanged(groupUuid, operatorUuid, memberUuid, req.Role)
+
+	return nil
+}
+
+// ensureOwner 校验用户是群主
+func (this *GroupRoleService) ensureOwner(groupUuid string, userUuid string) *common.ServiceError {
+	_, member, err := GroupSvc.findGroupAndMember(groupUuid, userUuid)
+
+	if err != nil {
+		return err
+	}
+
+	if member.Role != model.GroupRoleOwner {
+		return common.ErrGroupPermissionDenied
+	}
+
+	return nil
+}
+
+// findCustomRole 校验用户是群主，并查询可修改的自定义角色
+func (this *GroupRoleService) findCustomRole(
+	groupUuid string,
+	operatorUuid string,
+	name string,
+) (*model.GroupRole, *common.ServiceError) {
+	if err := this.ensureOwner(groupUuid, operatorUuid); err != nil {
+		return nil, err
+	}
+
+	if _, ok := model.BuiltinGroupRolePermissions(name); ok {
+		return nil, common.ErrGroupRoleReserved
+	}
+
+	role, err := repository.GroupRoleRepo.FindByName(groupUuid, name)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group role failed: %w", err))
+	}
+
+	if role == nil {
+		return nil, common.ErrGroupRoleNotFound
+	}
+
+	return role, nil
+}
+
+func (this *GroupRoleService) notifyRoleChanged(groupUuid string, operatorUuid string, memberUuid string, role string) {
+	GroupSvc.broadcast(groupUuid, ws.Event{
+		Type: ws.EventGroupMemberRoleChanged,
+		Data: dto.GroupMemberRoleChangedData{
+			GroupUuid:    groupUuid,
+			OperatorUuid: operatorUuid,
+			UserUuid:     memberUuid,
+			Role:         role,
+		},
+	})
+}
+
+func toGroupRoleData(role *model.GroupRole) *dto.GroupRoleData {
+	return &dto.GroupRoleData{
+		Name:        role.Name,
+		Builtin:     false,
+		Permissions: role.Permissions.Names(),
+	}
+}
+
+var GroupRoleSvc = &GroupRoleService{}
@@ -0,0 +1,13 @@
+package service
+
+// StartJobs 启动后台定时任务，需要在数据库初始化之后调用
+func StartJobs() {
+	go PresenceSvc.runSweeper()
+	go TypingSvc.runPruner()
+	go FriendSvc.runExpirer()
+	go StatusSvc.runExpirer()
+	go GroupModerationSvc.runMuteExpirer()
+	go SessionSvc.runPruner()
+	go TwoFactorSvc.runPruner()
+	go EmailSvc.runPruner()
+}
@@ -0,0 +1,702 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+const (
+	// 未指定 limit 时默认返回的消息条数
+	defaultMessageLimit = 20
+	// 未配置 message.editWindow 时的默认值
+	defaultEditWindow = 15 * time.Minute
+)
+
+type MessageService struct {
+}
+
+// SendDirectMessage 向指定用户发送单聊消息，会话不存在时自动创建
+func (this *MessageService) SendDirectMessage(
+	senderUuid string,
+	receiverUuid string,
+	req dto.SendMessageRequest,
+) (*dto.MessageData, *common.ServiceError) {
+	if senderUuid == receiverUuid {
+		return nil, common.ErrMessageSelf
+	}
+
+	receiver, err := repository.UserRepo.FindByUuid(receiverUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if receiver == nil {
+		return nil, common.ErrReceiverNotFound
+	}
+
+	if err := BlockSvc.ensureNotBlocked(senderUuid, receiverUuid); err != nil {
+		return nil, err
+	}
+
+	conversation, err := repository.ConversationRepo.FindOrCreateDirect(senderUuid, receiverUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find or create direct conversation failed: %w", err))
+	}
+
+	// 推送给双方的所有在线设备，发送者的其他设备也需要同步
+	return this.createMessage(conversation.Uuid, senderUuid, req, []string{senderUuid, receiverUuid})
+}
+
+// SendGroupMessage 向群聊发送消息，需要 send_message 权限，非文本消息还需要 send_media 权限，被禁言时不能发送
+func (this *MessageService) SendGroupMessage(
+	senderUuid string,
+	groupUuid string,
+	req dto.SendMessageRequest,
+) (*dto.MessageData, *common.ServiceError) {
+	perm := model.GroupPermSendMessage
+	if req.Type != "" && req.Type != model.MessageTypeText {
+		perm |= model.GroupPermSendMedia
+	}
+
+	group, member, err := GroupSvc.authorize(groupUuid, senderUuid, perm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if member.IsMuted() {
+		return nil, common.ErrGroupMemberMuted
+	}
+
+	memberUuids, repoErr := repository.GroupRepo.FindMemberUuids(groupUuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find group member uuids failed: %w", repoErr))
+	}
+
+	return this.createMessage(group.ConversationUuid, senderUuid, req, memberUuids)
+}
+
+// sendSystemMessage 在群内发送系统消息，内容序列化为 JSON
+func (this *MessageService) sendSystemMessage(
+	group *model.Group,
+	senderUuid string,
+	content dto.SystemMessageContent,
+) error {
+	data, err := json.Marshal(content)
+
+	if err != nil {
+		return err
+	}
+
+	memberUuids, err := repository.GroupRepo.FindMemberUuids(group.Uuid)
+
+	if err != nil {
+		return err
+	}
+
+	req := dto.SendMessageRequest{Type: model.MessageTypeSystem, Content: string(data)}
+	if _, serviceErr := this.createMessage(group.ConversationUuid, senderUuid, req, memberUuids); serviceErr != nil {
+		return serviceErr
+	}
+
+	return nil
+}
+
+// ListDirectMessages 分页查询当前用户与指定用户之间的单聊消息
+func (this *MessageService) ListDirectMessages(
+	userUuid string,
+	peerUuid string,
+	req dto.ListMessagesRequest,
+) ([]dto.MessageData, *common.CursorMeta, *common.ServiceError) {
+	peer, err := repository.UserRepo.FindByUuid(peerUuid)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if peer == nil {
+		return nil, nil, common.ErrUserNotFound
+	}
+
+	conversation, err := repository.ConversationRepo.FindDirect(userUuid, peerUuid)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find direct conversation failed: %w", err))
+	}
+
+	// 还没有聊过天
+	if conversation == nil {
+		return []dto.MessageData{}, &common.CursorMeta{}, nil
+	}
+
+	return this.listPage(conversation.Uuid, "", userUuid, req)
+}
+
+// ListGroupMessages 分页查询群聊消息，仅群成员可查看
+func (this *MessageService) ListGroupMessages(
+	userUuid string,
+	groupUuid string,
+	req dto.ListMessagesRequest,
+) ([]dto.MessageData, *common.CursorMeta, *common.ServiceError) {
+	group, _, err := GroupSvc.findGroupAndMember(groupUuid, userUuid)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return this.listPage(group.ConversationUuid, "", userUuid, req)
+}
+
+// ListConversationMessages 分页查询会话的消息历史，仅会话参与者可查看
+func (this *MessageService) ListConversationMessages(
+	userUuid string,
+	conversationUuid string,
+	req dto.ListMessagesRequest,
+) ([]dto.MessageData, *common.CursorMeta, *common.ServiceError) {
+	conversation, err := ConversationSvc.findConversationForUser(conversationUuid, userUuid)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return this.listPage(conversation.Uuid, "", userUuid, req)
+}
+
+// EditMessage 编辑自己发送的文本消息，仅在发送后的可编辑时间内允许
+func (this *MessageService) EditMessage(
+	userUuid string,
+	messageUuid string,
+	req dto.EditMessageRequest,
+) (*dto.MessageData, *common.ServiceError) {
+	message, conversation, err := this.findMessageForUser(messageUuid, userUuid, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if message.SenderUuid != userUuid {
+		return nil, common.ErrMessagePermissionDenied
+	}
+
+	if message.Type != model.MessageTypeText {
+		return nil, common.ErrMessageNotEditable
+	}
+
+	if time.Since(message.CreatedAt) > editWindow() {
+		return nil, common.ErrMessageEditExpired
+	}
+
+	now := time.Now()
+	if repoErr := repository.MessageRepo.UpdateContent(message, req.Content, now); repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo update message content failed: %w", repoErr))
+	}
+
+	message.Content = req.Content
+	message.EditedAt = &now
+	list := []dto.MessageData{*toMessageData(message)}
+
+	if err := this.attachReferences(list); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message references failed: %w", err))
+	}
+	messageData := &list[0]
+
+	this.broadcast(conversation, ws.Event{Type: ws.EventMessageEdited, Data: messageData})
+
+	return messageData, nil
+}
+
+// DeleteMessage 删除消息
+// scope 为 me 时仅对自己隐藏，任何参与者都可以操作；为 everyone 时撤回消息，仅发送者可以操作
+func (this *MessageService) DeleteMessage(userUuid string, messageUuid string, scope string) *common.ServiceError {
+	if scope != model.MessageDeleteScopeMe && scope != model.MessageDeleteScopeEveryone {
+		return common.ErrDeleteScopeInvalid
+	}
+
+	message, conversation, err := this.findMessageForUser(messageUuid, userUuid, scope == model.MessageDeleteScopeMe)
+
+	if err != nil {
+		return err
+	}
+
+	deleted := dto.MessageDeletedData{
+		ConversationUuid: message.ConversationUuid,
+		MessageUuid:      message.Uuid,
+		Scope:            scope,
+	}
+
+	if scope == model.MessageDeleteScopeMe {
+		if repoErr := repository.MessageRepo.Hide(message.Uuid, userUuid); repoErr != nil {
+			return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo hide message failed: %w", repoErr))
+		}
+
+		// 同步给自己的其他设备
+		ws.ClientHub.SendToUser(userUuid, ws.Event{Type: ws.EventMessageDeleted, Data: deleted})
+		return nil
+	}
+
+	// 系统消息记录群管理操作，不能撤回
+	if message.SenderUuid != userUuid || message.Type == model.MessageTypeSystem {
+		return common.ErrMessagePermissionDenied
+	}
+
+	if repoErr := repository.MessageRepo.DeleteForEveryone(message); repoErr != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo delete message failed: %w", repoErr))
+	}
+
+	this.broadcast(conversation, ws.Event{Type: ws.EventMessageDeleted, Data: deleted})
+
+	return nil
+}
+
+// ListRevisions 查询消息的编辑历史，仅会话参与者可查看
+func (this *MessageService) ListRevisions(userUuid string, messageUuid string) ([]dto.MessageRevisionData, *common.ServiceError) {
+	message, _, err := this.findMessageForUser(messageUuid, userUuid, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, repoErr := repository.MessageRepo.FindRevisions(message.Uuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message revisions failed: %w", repoErr))
+	}
+
+	list := make([]dto.MessageRevisionData, 0, len(revisions))
+	for _, revision := range revisions {
+		list = append(list, dto.MessageRevisionData{
+			Content:  revision.Content,
+			EditedAt: revision.CreatedAt,
+		})
+	}
+
+	return list, nil
+}
+
+// PinGroupMessage 置顶或取消置顶群消息，需要 pin_message 权限，状态没有变化时不推送事件
+func (this *MessageService) PinGroupMessage(
+	operatorUuid string,
+	groupUuid string,
+	messageUuid string,
+	pinned bool,
+) *common.ServiceError {
+	group, _, err := GroupSvc.authorize(groupUuid, operatorUuid, model.GroupPermPinMessage)
+
+	if err != nil {
+		return err
+	}
+
+	message, repoErr := repository.MessageRepo.FindByUuid(messageUuid)
+
+	if repoErr != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message by uuid failed: %w", repoErr))
+	}
+
+	if message == nil || message.ConversationUuid != group.ConversationUuid {
+		return common.ErrMessageNotFound
+	}
+
+	changed, repoErr := repository.MessageRepo.UpdatePinned(message, pinned, operatorUuid)
+
+	if repoErr != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo update message pinned failed: %w", repoErr))
+	}
+
+	if !changed {
+		return nil
+	}
+
+	eventType := ws.EventMessageUnpinned
+	if pinned {
+		eventType = ws.EventMessagePinned
+	}
+
+	GroupSvc.broadcast(groupUuid, ws.Event{
+		Type: eventType,
+		Data: dto.MessagePinData{
+			ConversationUuid: message.ConversationUuid,
+			MessageUuid:      message.Uuid,
+			OperatorUuid:     operatorUuid,
+			PinnedAt:         message.PinnedAt,
+		},
+	})
+
+	return nil
+}
+
+// ListGroupPins 查询群内置顶的消息，按置顶时间倒序，仅群成员可查看
+func (this *MessageService) ListGroupPins(userUuid string, groupUuid string) ([]dto.MessageData, *common.ServiceError) {
+	group, _, err := GroupSvc.findGroupAndMember(groupUuid, userUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages, repoErr := repository.MessageRepo.FindPinned(group.ConversationUuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find pinned messages failed: %w", repoErr))
+	}
+
+	list := make([]dto.MessageData, 0, len(messages))
+	for i := range messages {
+		list = append(list, *toMessageData(&messages[i]))
+	}
+
+	if err := this.attachReferences(list); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message references failed: %w", err))
+	}
+
+	return list, nil
+}
+
+// findMessageForUser 查询消息，并校验用户是否为消息所在会话的参与者
+// withDeleted 为 true 时包括已撤回的消息
+func (this *MessageService) findMessageForUser(
+	messageUuid string,
+	userUuid string,
+	withDeleted bool,
+) (*model.Message, *model.Conversation, *common.ServiceError) {
+	var message *model.Message
+	var err error
+
+	if withDeleted {
+		message, err = repository.MessageRepo.FindByUuidWithDeleted(messageUuid)
+	} else {
+		message, err = repository.MessageRepo.FindByUuid(messageUuid)
+	}
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message by uuid failed: %w", err))
+	}
+
+	if message == nil {
+		return nil, nil, common.ErrMessageNotFound
+	}
+
+	conversation, serviceErr := ConversationSvc.findConversationForUser(message.ConversationUuid, userUuid)
+
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+
+	return message, conversation, nil
+}
+
+// broadcast 推送事件给会话的所有参与者
+func (this *MessageService) broadcast(conversation *model.Conversation, event ws.Event) {
+	participantUuids, err := ConversationSvc.participantUuids(conversation)
+
+	if err != nil {
+		log.Logger.Error("查询会话成员失败", log.String("conversationUuid", conversation.Uuid), log.Any("err", err))
+		return
+	}
+
+	ws.ClientHub.SendToUsers(participantUuids, event)
+}
+
+// ListThreadReplies 分页查询话题的回复，仅会话参与者可查看
+func (this *MessageService) ListThreadReplies(
+	userUuid string,
+	rootUuid string,
+	req dto.ListMessagesRequest,
+) ([]dto.MessageData, *common.CursorMeta, *common.ServiceError) {
+	// 根消息被撤回后话题仍然保留
+	root, _, err := this.findMessageForUser(rootUuid, userUuid, true)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if root.ThreadRootUuid != "" {
+		return nil, nil, common.ErrThreadNested
+	}
+
+	return this.listPage(root.ConversationUuid, root.Uuid, userUuid, req)
+}
+
+// createMessage 保存消息，并推送给 receiverUuids 的所有在线设备
+// 话题回复只推送给话题参与者，receiverUuids 只会收到 thread.updated 事件
+func (this *MessageService) createMessage(
+	conversationUuid string,
+	senderUuid string,
+	req dto.SendMessageRequest,
+	receiverUuids []string,
+) (*dto.MessageData, *common.ServiceError) {
+	message := &model.Message{
+		ConversationUuid: conversationUuid,
+		SenderUuid:       senderUuid,
+		Type:             req.Type,
+		Content:          req.Content,
+		ReplyToUuid:      req.ReplyToUuid,
+		ThreadRootUuid:   req.ThreadRootUuid,
+	}
+	if message.Type == "" {
+		message.Type = model.MessageTypeText
+	}
+
+	var root *model.Message
+	if req.ThreadRootUuid != "" {
+		found, err := this.findReference(conversationUuid, req.ThreadRootUuid)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if found.ThreadRootUuid != "" {
+			return nil, common.ErrThreadNested
+		}
+		root = found
+	}
+
+	if req.ReplyToUuid != "" {
+		if _, err := this.findReference(conversationUuid, req.ReplyToUuid); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := repository.MessageRepo.CreateMessage(message, root); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create message failed: %w", err))
+	}
+
+	messageData := toMessageData(message)
+	list := []dto.MessageData{*messageData}
+
+	if err := this.attachReferences(list); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message references failed: %w", err))
+	}
+	messageData = &list[0]
+
+	if root == nil {
+		ws.ClientHub.SendToUsers(receiverUuids, ws.Event{Type: ws.EventMessageNew, Data: messageData})
+		return messageData, nil
+	}
+
+	participants, err := repository.MessageRepo.FindThreadParticipants([]string{root.Uuid})
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find thread participants failed: %w", err))
+	}
+
+	participantUuids := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		// 已经离开会话的参与者不再接收回复
+		if slices.Contains(receiverUuids, participant.UserUuid) {
+			participantUuids = append(participantUuids, participant.UserUuid)
+		}
+	}
+
+	ws.ClientHub.SendToUsers(participantUuids, ws.Event{Type: ws.EventMessageNew, Data: messageData})
+	ws.ClientHub.SendToUsers(receiverUuids, ws.Event{
+		Type: ws.EventThreadUpdated,
+		Data: dto.ThreadUpdatedData{
+			ConversationUuid: conversationUuid,
+			ThreadRootUuid:   root.Uuid,
+			ReplyCount:       root.ReplyCount + 1,
+			LastReplyAt:      &message.CreatedAt,
+		},
+	})
+
+	return messageData, nil
+}
+
+// findReference 查询被回复的消息，必须属于同一会话且未被撤回
+func (this *MessageService) findReference(conversationUuid string, messageUuid string) (*model.Message, *common.ServiceError) {
+	message, err := repository.MessageRepo.FindByUuid(messageUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message by uuid failed: %w", err))
+	}
+
+	if message == nil || message.ConversationUuid != conversationUuid {
+		return nil, common.ErrReplyTargetNotFound
+	}
+
+	return message, nil
+}
+
+// attachReferences 为消息列表填充引用的消息摘要和话题信息
+func (this *MessageService) attachReferences(messages []dto.MessageData) error {
+	quotedUuids := []string{}
+	rootUuids := []string{}
+	for _, message := range messages {
+		if message.ReplyTo != nil {
+			quotedUuids = append(quotedUuids, message.ReplyTo.Uuid)
+		}
+		if message.Thread != nil {
+			rootUuids = append(rootUuids, message.Uuid)
+		}
+	}
+
+	quotedMessages, err := repository.MessageRepo.FindByUuidsWithDeleted(quotedUuids)
+
+	if err != nil {
+		return err
+	}
+
+	quotes := make(map[string]*dto.QuotedMessageData, len(quotedMessages))
+	for i := range quotedMessages {
+		quoted := toMessageData(&quotedMessages[i])
+		quotes[quoted.Uuid] = &dto.QuotedMessageData{
+			Uuid:       quoted.Uuid,
+			SenderUuid: quoted.SenderUuid,
+			Type:       quoted.Type,
+			Content:    quoted.Content,
+			Deleted:    quoted.Deleted,
+		}
+	}
+
+	participants, err := repository.MessageRepo.FindThreadParticipants(rootUuids)
+
+	if err != nil {
+		return err
+	}
+
+	participantUuids := make(map[string][]string)
+	for _, participant := range participants {
+		participantUuids[participant.ThreadRootUuid] = append(participantUuids[participant.ThreadRootUuid], participant.UserUuid)
+	}
+
+	for i := range messages {
+		if messages[i].ReplyTo != nil {
+			messages[i].ReplyTo = quotes[messages[i].ReplyTo.Uuid]
+		}
+		if messages[i].Thread != nil {
+			messages[i].Thread.ParticipantUuids = participantUuids[messages[i].Uuid]
+		}
+	}
+
+	return nil
+}
+
+// listPage 基于游标分页查询消息，返回的消息始终按时间正序排列
+func (this *MessageService) listPage(
+	conversationUuid string,
+	threadRootUuid string,
+	viewerUuid string,
+	req dto.ListMessagesRequest,
+) ([]dto.MessageData, *common.CursorMeta, *common.ServiceError) {
+	if req.Before != "" && req.After != "" {
+		return nil, nil, common.ErrCursorConflict
+	}
+
+	after := req.After != ""
+	rawCursor := req.Before
+	if after {
+		rawCursor = req.After
+	}
+
+	var cursor *common.Cursor
+	if rawCursor != "" {
+		decoded, err := common.DecodeCursor(rawCursor)
+		if err != nil {
+			return nil, nil, common.WrapServiceError(common.ErrCursorInvalid, err)
+		}
+		cursor = decoded
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultMessageLimit
+	}
+
+	// 多查一条用于判断是否还有更多数据
+	messages, err := repository.MessageRepo.FindPage(conversationUuid, threadRootUuid, viewerUuid, cursor, after, limit+1)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message page failed: %w", err))
+	}
+
+	meta := &common.CursorMeta{HasMore: len(messages) > limit}
+	if meta.HasMore {
+		messages = messages[:limit]
+	}
+
+	if len(messages) > 0 {
+		// messages 按请求方向排序：第一条离游标最近，最后一条离游标最远
+		first, last := messages[0], messages[len(messages)-1]
+		if meta.HasMore {
+			meta.NextCursor = common.EncodeCursor(last.CreatedAt, last.ID)
+		}
+		meta.PrevCursor = common.EncodeCursor(first.CreatedAt, first.ID)
+	}
+
+	// 向前翻页时按倒序查询，需要反转为正序
+	if !after {
+		slices.Reverse(messages)
+	}
+
+	list := make([]dto.MessageData, 0, len(messages))
+	for i := range messages {
+		list = append(list, *toMessageData(&messages[i]))
+	}
+
+	if err := ReactionSvc.attachReactions(list, viewerUuid); err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find reaction summaries failed: %w", err))
+	}
+
+	if err := this.attachReferences(list); err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message references failed: %w", err))
+	}
+
+	return list, meta, nil
+}
+
+func toMessageData(message *model.Message) *dto.MessageData {
+	messageData := &dto.MessageData{
+		Uuid:             message.Uuid,
+		ConversationUuid: message.ConversationUuid,
+		SenderUuid:       message.SenderUuid,
+		Type:             message.Type,
+		Content:          message.Content,
+		CreateAt:         message.CreatedAt,
+		EditedAt:         message.EditedAt,
+		ThreadRootUuid:   message.ThreadRootUuid,
+		PinnedAt:         message.PinnedAt,
+	}
+
+	// 引用的消息和话题参与者由 attachReferences 批量填充
+	if message.ReplyToUuid != "" {
+		messageData.ReplyTo = &dto.QuotedMessageData{Uuid: message.ReplyToUuid}
+	}
+	if message.ReplyCount > 0 {
+		messageData.Thread = &dto.ThreadData{
+			ReplyCount:  message.ReplyCount,
+			LastReplyAt: message.LastReplyAt,
+		}
+	}
+
+	// 墓碑只保留会话中的位置，不返回内容
+	if message.DeletedAt.Valid {
+		messageData.Content = ""
+		messageData.EditedAt = nil
+		messageData.ReplyTo = nil
+		messageData.PinnedAt = nil
+		messageData.Deleted = true
+	}
+
+	return messageData
+}
+
+func editWindow() time.Duration {
+	seconds := config.GetConfig().Message.EditWindow
+	if seconds <= 0 {
+		return defaultEditWindow
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+var MessageSvc = &MessageService{}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+// 在线状态
+const (
+	PresenceOnline  = "online"
+	PresenceAway    = "away"
+	PresenceOffline = "offline"
+)
+
+const (
+	// 未配置 presence.awayTimeout 时的默认值
+	defaultAwayTimeout = 5 * time.Minute
+	// 定期检查心跳超时的周期
+	presenceSweepInterval = 30 * time.Second
+)
+
+// PresenceService 根据用户的 WebSocket 连接和心跳计算在线状态
+// 用户的任一设备处于活跃状态即为 online，所有设备都离开为 away，没有连接为 offline
+type PresenceService struct {
+	mu sync.Mutex
+	// 最近一次推送的状态，只保存非 offline 的用户
+	states map[string]string
+}
+
+// HandleConnect 在连接建立后刷新在线状态
+func (this *PresenceService) HandleConnect(client *ws.Client) {
+	this.refresh(client.UserId)
+}
+
+// HandleDisconnect 在连接断开后刷新在线状态
+func (this *PresenceService) HandleDisconnect(client *ws.Client) {
+	this.refresh(client.UserId)
+}
+
+// Heartbeat 记录客户端心跳，并刷新在线状态
+func (this *PresenceService) Heartbeat(client *ws.Client, req dto.HeartbeatRequest) *common.ServiceError {
+	client.Heartbeat(req.Away)
+	this.refresh(client.UserId)
+	return nil
+}
+
+// State 获取用户当前的在线状态
+func (this *PresenceService) State(userUuid string) string {
+	clients := ws.ClientHub.UserClients(userUuid)
+
+	if len(clients) == 0 {
+		return PresenceOffline
+	}
+
+	timeout := awayTimeout()
+	for _, client := range clients {
+		if !client.IsAway(timeout) {
+			return PresenceOnline
+		}
+	}
+
+	return PresenceAway
+}
+
+// refresh 重新计算用户的在线状态，状态变化时持久化最后在线时间并通知联系人
+func (this *PresenceService) refresh(userUuid string) {
+	state := this.State(userUuid)
+
+	this.mu.Lock()
+	previous, ok := this.states[userUuid]
+	if !ok {
+		previous = PresenceOffline
+	}
+	if state == previous {
+		this.mu.Unlock()
+		return
+	}
+	if state == PresenceOffline {
+		delete(this.states, userUuid)
+	} else {
+		this.states[userUuid] = state
+	}
+	this.mu.Unlock()
+
+	presence := dto.PresenceData{UserUuid: userUuid, State: state}
+
+	if state == PresenceOffline {
+		now := time.Now()
+		if err := repository.UserRepo.UpdateLastSeenAt(userUuid, now); err != nil {
+			log.Logger.Error("更新最后在线时间失败", log.String("userUuid", userUuid), log.Any("err", err))
+		}
+		presence.LastSeenAt = &now
+	}
+
+	this.notifyContacts(presence)
+}
+
+// notifyContacts 将在线状态变更推送给用户的联系人
+func (this *PresenceService) notifyContacts(presence dto.PresenceData) {
+	contactUuids, err := this.contactUuids(presence.UserUuid)
+
+	if err != nil {
+		log.Logger.Error("查询联系人失败", log.String("userUuid", presence.UserUuid), log.Any("err", err))
+		return
+	}
+
+	if len(contactUuids) == 0 {
+		return
+	}
+
+	if presence.LastSeenAt == nil {
+		ws.ClientHub.SendToUsers(contactUuids, ws.Event{Type: ws.EventPresenceChanged, Data: presence})
+		return
+	}
+
+	// 最后在线时间按用户的 lastSeenVisibility 设置推送，不可见的联系人只收到状态变更
+	hidden := presence
+	hidden.LastSeenAt = nil
+
+	setting, err := repository.SettingRepo.FindByUser(presence.UserUuid)
+
+	if err != nil {
+		log.Logger.Error("查询用户设置失败", log.String("userUuid", presence.UserUuid), log.Any("err", err))
+		ws.ClientHub.SendToUsers(contactUuids, ws.Event{Type: ws.EventPresenceChanged, Data: hidden})
+		return
+	}
+
+	switch setting.LastSeenVisibility {
+	case model.VisibilityEveryone:
+		ws.ClientHub.SendToUsers(contactUuids, ws.Event{Type: ws.EventPresenceChanged, Data: presence})
+	case model.VisibilityContacts:
+		friendUuids, err := repository.FriendRepo.FindFriendUuids(presence.UserUuid)
+
+		if err != nil {
+			log.Logger.Error("查询好友失败", log.String("userUuid", presence.UserUuid), log.Any("err", err))
+			friendUuids = nil
+		}
+
+		visibleUuids := make([]string, 0, len(contactUuids))
+		hiddenUuids := make([]string, 0, len(contactUuids))
+		for _, contactUuid := range contactUuids {
+			if slices.Contains(friendUuids, contactUuid) {
+				visibleUuids = append(visibleUuids, contactUuid)
+			} else {
+				hiddenUuids = append(hiddenUuids, contactUuid)
+			}
+		}
+
+		ws.ClientHub.SendToUsers(visibleUuids, ws.Event{Type: ws.EventPresenceChanged, Data: presence})
+		ws.ClientHub.SendToUsers(hiddenUuids, ws.Event{Type: ws.EventPresenceChanged, Data: hidden})
+	default:
+		ws.ClientHub.SendToUsers(contactUuids, ws.Event{Type: ws.EventPresenceChanged, Data: hidden})
+	}
+}
+
+// contactUuids 查询需要接收在线状态的联系人：好友以及有过单聊的用户，排除存在屏蔽关系的用户
+func (this *PresenceService) contactUuids(userUuid string) ([]string, error) {
+	peerUuids, err := repository.ConversationRepo.FindDirectPeerUuids(userUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	friendUuids, err := repository.FriendRepo.FindFriendUuids(userUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	relatedUuids, err := repository.BlockRepo.FindRelatedUuids(userUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contactUuids := uniqueUuids(append(peerUuids, friendUuids...), userUuid)
+
+	return slices.DeleteFunc(contactUuids, func(contactUuid string) bool {
+		return slices.Contains(relatedUuids, contactUuid)
+	}), nil
+}
+
+// runSweeper 定期检查在线用户的心跳，将超时未心跳的用户切换为离开状态
+func (this *PresenceService) runSweeper() {
+	ticker := time.NewTicker(presenceSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		this.mu.Lock()
+		userUuids := make([]string, 0, len(this.states))
+		for userUuid := range this.states {
+			userUuids = append(userUuids, userUuid)
+		}
+		this.mu.Unlock()
+
+		for _, userUuid := range userUuids {
+			this.refresh(userUuid)
+		}
+	}
+}
+
+func awayTimeout() time.Duration {
+	seconds := config.GetConfig().Presence.AwayTimeout
+	if seconds <= 0 {
+		return defaultAwayTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+var PresenceSvc = &PresenceService{states: make(map[string]string)}
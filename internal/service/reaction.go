@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+// 未配置 message.maxGroupReactions 时的默认值
+const defaultMaxGroupReactions = 20
+
+type ReactionService struct {
+}
+
+// AddReaction 对可见的消息添加表情回应，重复添加时直接返回当前结果
+// 群聊中每条消息的不同表情数量受 message.maxGroupReactions 限制
+func (this *ReactionService) AddReaction(
+	userUuid string,
+	messageUuid string,
+	req dto.AddReactionRequest,
+) (*dto.ReactionChangedData, *common.ServiceError) {
+	message, conversation, err := MessageSvc.findMessageForUser(messageUuid, userUuid, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &model.MessageReaction{
+		MessageUuid: message.Uuid,
+		UserUuid:    userUuid,
+		Emoji:       req.Emoji,
+	}
+
+	var added bool
+	var repoErr error
+
+	if conversation.Type == model.ConversationTypeGroup {
+		var exceeded bool
+		added, exceeded, repoErr = repository.ReactionRepo.AddWithLimit(reaction, maxGroupReactions())
+
+		if repoErr == nil && exceeded {
+			return nil, common.ErrReactionLimitExceeded
+		}
+	} else {
+		added, repoErr = repository.ReactionRepo.Add(reaction)
+	}
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo add reaction failed: %w", repoErr))
+	}
+
+	return this.changed(conversation, message, userUuid, req.Emoji, true, added)
+}
+
+// RemoveReaction 移除自己的表情回应，未回应过时直接返回当前结果
+func (this *ReactionService) RemoveReaction(
+	userUuid string,
+	messageUuid string,
+	emoji string,
+) (*dto.ReactionChangedData, *common.ServiceError) {
+	message, conversation, err := MessageSvc.findMessageForUser(messageUuid, userUuid, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	removed, repoErr := repository.ReactionRepo.Remove(message.Uuid, userUuid, emoji)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo remove reaction failed: %w", repoErr))
+	}
+
+	return this.changed(conversation, message, userUuid, emoji, false, removed)
+}
+
+// changed 统计变更后的回应人数，有实际变更时推送给会话参与者
+func (this *ReactionService) changed(
+	conversation *model.Conversation,
+	message *model.Message,
+	userUuid string,
+	emoji string,
+	added bool,
+	affected bool,
+) (*dto.ReactionChangedData, *common.ServiceError) {
+	count, err := repository.ReactionRepo.CountByEmoji(message.Uuid, emoji)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo count reactions failed: %w", err))
+	}
+
+	data := &dto.ReactionChangedData{
+		ConversationUuid: conversation.Uuid,
+		MessageUuid:      message.Uuid,
+		UserUuid:         userUuid,
+		Emoji:            emoji,
+		Added:            added,
+		Count:            int(count),
+	}
+
+	if affected {
+		MessageSvc.broadcast(conversation, ws.Event{Type: ws.EventMessageReaction, Data: data})
+	}
+
+	return data, nil
+}
+
+// attachReactions 为消息列表填充表情回应的聚合结果
+func (this *ReactionService) attachReactions(messages []dto.MessageData, viewerUuid string) error {
+	messageUuids := make([]string, 0, len(messages))
+	for _, message := range messages {
+		if !message.Deleted {
+			messageUuids = append(messageUuids, message.Uuid)
+		}
+	}
+
+	summaries, err := repository.ReactionRepo.FindSummaries(messageUuids, viewerUuid)
+
+	if err != nil {
+		return err
+	}
+
+	reactions := make(map[string][]dto.ReactionData)
+	for _, summary := range summaries {
+		reactions[summary.MessageUuid] = append(reactions[summary.MessageUuid], dto.ReactionData{
+			Emoji:   summary.Emoji,
+			Count:   summary.Count,
+			Reacted: summary.Reacted,
+		})
+	}
+
+	for i := range messages {
+		messages[i].Reactions = reactions[messages[i].Uuid]
+	}
+
+	return nil
+}
+
+func maxGroupReactions() int {
+	limit := config.GetConfig().Message.MaxGroupReactions
+	if limit <= 0 {
+		return defaultMaxGroupReactions
+	}
+	return limit
+}
+
+var ReactionSvc = &ReactionService{}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+type ReceiptService struct {
+}
+
+// Acknowledge 确认会话中某条消息（及之前的所有消息）已送达或已读，并实时通知相关消息的发送者
+// 用户关闭已读回执后，仍会记录已读位置，但不会通知发送者
+func (this *ReceiptService) Acknowledge(
+	userUuid string,
+	conversationUuid string,
+	req dto.AckReceiptRequest,
+) (*dto.ReceiptData, *common.ServiceError) {
+	conversation, err := ConversationSvc.findConversationForUser(conversationUuid, userUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	message, repoErr := repository.MessageRepo.FindByUuid(req.MessageUuid)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message by uuid failed: %w", repoErr))
+	}
+
+	if message == nil || message.ConversationUuid != conversation.Uuid {
+		return nil, common.ErrMessageNotFound
+	}
+
+	now := time.Now()
+	read := req.Status == model.ReceiptStatusRead
+
+	before, after, repoErr := repository.ReceiptRepo.Advance(conversation.Uuid, userUuid, message.ID, read, now)
+
+	if repoErr != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo advance receipt failed: %w", repoErr))
+	}
+
+	receipt := &dto.ReceiptData{
+		ConversationUuid: conversation.Uuid,
+		UserUuid:         userUuid,
+		MessageUuid:      message.Uuid,
+		Status:           req.Status,
+		At:               now,
+	}
+
+	if after.DeliveredMessageId > before.DeliveredMessageId {
+		this.notifySenders(conversation.Uuid, before.DeliveredMessageId, after.DeliveredMessageId, dto.ReceiptData{
+			ConversationUuid: conversation.Uuid,
+			UserUuid:         userUuid,
+			MessageUuid:      message.Uuid,
+			Status:           model.ReceiptStatusDelivered,
+			At:               now,
+		})
+	}
+
+	if after.ReadMessageId > before.ReadMessageId {
+		setting, repoErr := repository.SettingRepo.FindByUser(userUuid)
+
+		if repoErr != nil {
+			return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find setting by user failed: %w", repoErr))
+		}
+
+		if setting.ReadReceipts {
+			this.notifySenders(conversation.Uuid, before.ReadMessageId, after.ReadMessageId, *receipt)
+		}
+	}
+
+	return receipt, nil
+}
+
+// GetMessageReceipts 查询消息的送达、已读人数以及已读用户列表，仅消息发送者可以查看
+func (this *ReceiptService) GetMessageReceipts(
+	userUuid string,
+	messageUuid string,
+	req dto.PageRequest,
+) (*dto.MessageReceiptsData, *common.PageMeta, *common.ServiceError) {
+	message, err := repository.MessageRepo.FindByUuid(messageUuid)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find message by uuid failed: %w", err))
+	}
+
+	if message == nil {
+		return nil, nil, common.ErrMessageNotFound
+	}
+
+	if message.SenderUuid != userUuid {
+		return nil, nil, common.ErrReceiptsForbidden
+	}
+
+	conversation, serviceErr := ConversationSvc.findConversationForUser(message.ConversationUuid, userUuid)
+
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+
+	participantUuids, err := ConversationSvc.participantUuids(conversation)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find participants failed: %w", err))
+	}
+
+	delivered, read, err := repository.ReceiptRepo.CountByMessage(conversation.Uuid, userUuid, message.ID)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo count receipts failed: %w", err))
+	}
+
+	req.Normalize()
+
+	receipts, err := repository.ReceiptRepo.FindReaders(conversation.Uuid, userUuid, message.ID, req.Offset(), req.PageSize)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find readers failed: %w", err))
+	}
+
+	readerUuids := make([]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		readerUuids = append(readerUuids, receipt.UserUuid)
+	}
+
+	users, err := repository.UserRepo.FindByUuids(readerUuids)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find users by uuids failed: %w", err))
+	}
+
+	userMap := make(map[string]*model.User, len(users))
+	for i := range users {
+		userMap[users[i].Uuid] = &users[i]
+	}
+
+	avatars, svcErr := UserSvc.visibleAvatars(userUuid, users)
+
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	seenBy := make([]dto.SeenByData, 0, len(receipts))
+	for _, receipt := range receipts {
+		seen := dto.SeenByData{Uuid: receipt.UserUuid}
+		if receipt.ReadAt != nil {
+			seen.ReadAt = *receipt.ReadAt
+		}
+		if user, ok := userMap[receipt.UserUuid]; ok {
+			seen.Username = user.Username
+			seen.Nickname = user.Nickname
+			seen.Avatar = avatars[user.Uuid]
+		}
+		seenBy = append(seenBy, seen)
+	}
+
+	return &dto.MessageReceiptsData{
+		MessageUuid:    message.Uuid,
+		RecipientCount: len(participantUuids) - 1,
+		DeliveredCount: delivered,
+		ReadCount:      read,
+		SeenBy:         seenBy,
+	}, &common.PageMeta{Total: read, Page: req.Page, PageSize: req.PageSize}, nil
+}
+
+// notifySenders 通知 (fromId, toId] 区间内消息的发送者回执状态变更
+func (this *ReceiptService) notifySenders(conversationUuid string, fromId uint, toId uint, receipt dto.ReceiptData) {
+	senderUuids, err := repository.MessageRepo.FindSenderUuidsInRange(conversationUuid, fromId, toId, receipt.UserUuid)
+
+	if err != nil {
+		log.Logger.Error("查询消息发送者失败", log.String("conversationUuid", conversationUuid), log.Any("err", err))
+		return
+	}
+
+	ws.ClientHub.SendToUsers(senderUuids, ws.Event{Type: ws.EventMessageReceipt, Data: receipt})
+}
+
+var ReceiptSvc = &ReceiptService{}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+const (
+	// 未配置 jwt.refreshTokenTtl 时的默认值
+	defaultRefreshTokenTtl = 30 * 24 * time.Hour
+	// 定期清理过期会话、Refresh Token 和吊销记录的周期
+	sessionPruneInterval = time.Hour
+	// jwt.revocationStore 配置为 memory 时使用内存存储吊销记录
+	revocationStoreMemory = "memory"
+	// 会话最近使用时间的更新间隔，避免每个请求都写数据库
+	sessionTouchInterval = time.Minute
+	// 保存的 User-Agent 的最大长度
+	maxUserAgentLength = 512
+	// 会话被吊销时断开 WebSocket 连接的原因
+	sessionRevokedReason = "session revoked"
+)
+
+// SessionService 管理登录会话，签发 Access Token 和 Refresh Token
+// 每次登录创建一个会话，该会话签发的所有 Token 都绑定到会话 uuid，吊销会话后这些 Token 立即失效
+// Refresh Token 每次使用后都会轮换，已使用的 Refresh Token 被再次使用时视为泄露，吊销整个会话
+// 退出登录时吊销当前会话以及当前的 Access Token
+type SessionService struct {
+	revocationsOnce sync.Once
+	revocations     repository.TokenRevocationStore
+}
+
+// Logout 退出登录，吊销当前会话以及当前的 Access Token
+func (this *SessionService) Logout(claims *common.Claims) *common.ServiceError {
+	if claims.SessionId != "" {
+		if err := this.revokeSessions(claims.UserId, []string{claims.SessionId}); err != nil {
+			return err
+		}
+	}
+
+	// 旧版本签发的 Token 没有 jti，无法吊销，只能等待过期
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	if err := this.revocationStore().Revoke(claims.ID, claims.ExpiresAt.Time); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("revoke token failed: %w", err))
+	}
+
+	return nil
+}
+
+// CheckToken 检查已通过签名和有效期校验的 Token 是否已被吊销，以及所属会话是否仍然有效
+func (this *SessionService) CheckToken(claims *common.Claims) *common.ServiceError {
+	if claims.ID != "" {
+		revoked, err := this.revocationStore().IsRevoked(claims.ID)
+
+		if err != nil {
+			return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("check token revocation failed: %w", err))
+		}
+
+		if revoked {
+			return common.ErrTokenRevoked
+		}
+	}
+
+	// 旧版本签发的 Token 没有绑定会话
+	if claims.SessionId == "" {
+		return nil
+	}
+
+	session, err := repository.SessionRepo.FindByUuid(claims.SessionId)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find session failed: %w", err))
+	}
+
+	if session == nil || !session.IsActive() {
+		return common.ErrTokenRevoked
+	}
+
+	if now := time.Now(); now.Sub(session.LastUsedAt) > sessionTouchInterval {
+		if err := repository.SessionRepo.UpdateLastUsed(session.Uuid, now); err != nil {
+			log.Logger.Error("更新会话最近使用时间失败", log.String("sessionUuid", session.Uuid), log.Any("err", err))
+		}
+	}
+
+	return nil
+}
+
+// ListSessions 查询用户所有有效的登录会话，最近使用的在前
+func (this *SessionService) ListSessions(claims *common.Claims) ([]dto.SessionData, *common.ServiceError) {
+	sessions, err := repository.SessionRepo.FindActiveByUser(claims.UserId, time.Now())
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find sessions failed: %w", err))
+	}
+
+	list := make([]dto.SessionData, 0, len(sessions))
+	for _, session := range sessions {
+		list = append(list, dto.SessionData{
+			Uuid:       session.Uuid,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			Ip:         session.Ip,
+			CreateAt:   session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			Current:    session.Uuid == claims.SessionId,
+		})
+	}
+
+	return list, nil
+}
+
+// RevokeSession 吊销用户的某个登录会话，该设备会被立即下线
+func (this *SessionService) RevokeSession(claims *common.Claims, sessionUuid string) *common.ServiceError {
+	session, err := repository.SessionRepo.FindByUuid(sessionUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find session failed: %w", err))
+	}
+
+	if session == nil || session.UserUuid != claims.UserId || !session.IsActive() {
+		return common.ErrSessionNotFound
+	}
+
+	return this.revokeSessions(claims.UserId, []string{session.Uuid})
+}
+
+// RevokeOtherSessions 吊销除当前会话以外的所有登录会话
+func (this *SessionService) RevokeOtherSessions(claims *common.Claims) *common.ServiceError {
+	sessions, err := repository.SessionRepo.FindActiveByUser(claims.UserId, time.Now())
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find sessions failed: %w", err))
+	}
+
+	uuids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Uuid != claims.SessionId {
+			uuids = append(uuids, session.Uuid)
+		}
+	}
+
+	return this.revokeSessions(claims.UserId, uuids)
+}
+
+// Refresh 使用 Refresh Token 换取新的 Access Token，并轮换 Refresh Token
+func (this *SessionService) Refresh(req dto.RefreshSessionRequest) (*dto.LoginResponseData, *common.ServiceError) {
+	token, err := repository.RefreshTokenRepo.FindByHash(model.HashRefreshToken(req.RefreshToken))
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find refresh token failed: %w", err))
+	}
+
+	if token == nil || token.RevokedAt != nil {
+		return nil, common.ErrInvalidRefreshToken
+	}
+
+	if token.UsedAt != nil {
+		return nil, this.revokeReused(token)
+	}
+
+	if !token.IsUsable() {
+		return nil, common.ErrInvalidRefreshToken
+	}
+
+	user, err := repository.UserRepo.FindByUuid(token.UserUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if user == nil {
+		return nil, common.ErrInvalidRefreshToken
+	}
+
+	next, refreshToken, err := model.NewRefreshToken(user.Uuid, token.FamilyUuid, refreshTokenTtl())
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate refresh token failed: %w", err))
+	}
+
+	rotated, err := repository.RefreshTokenRepo.Rotate(token, next)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo rotate refresh token failed: %w", err))
+	}
+
+	// 并发使用同一个 token 时只有一个请求可以轮换成功，其余请求同样视为重复使用
+	if !rotated {
+		return nil, this.revokeReused(token)
+	}
+
+	if err := repository.SessionRepo.Renew(token.FamilyUuid, time.Now(), next.ExpiresAt); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo renew session failed: %w", err))
+	}
+
+	return this.respond(user, next, refreshToken)
+}
+
+// issue 为用户创建新的登录会话，并签发该会话的 Access Token 和第一个 Refresh Token
+func (this *SessionService) issue(
+	user *model.User,
+	deviceName string,
+	userAgent string,
+	ip string,
+) (*dto.LoginResponseData, *common.ServiceError) {
+	sessionUuid := uuid.NewString()
+	token, refreshToken, err := model.NewRefreshToken(user.Uuid, sessionUuid, refreshTokenTtl())
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate refresh token failed: %w", err))
+	}
+
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	session := &model.Session{
+		Uuid:       sessionUuid,
+		UserUuid:   user.Uuid,
+		DeviceName: deviceName,
+		UserAgent:  userAgent,
+		Ip:         ip,
+		LastUsedAt: time.Now(),
+		ExpiresAt:  token.ExpiresAt,
+	}
+
+	if err := repository.SessionRepo.Create(session); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create session failed: %w", err))
+	}
+
+	if err := repository.RefreshTokenRepo.Create(token); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create refresh token failed: %w", err))
+	}
+
+	return this.respond(user, token, refreshToken)
+}
+
+func (this *SessionService) respond(
+	user *model.User,
+	token *model.RefreshToken,
+	refreshToken string,
+) (*dto.LoginResponseData, *common.ServiceError) {
+	accessToken, expireTime, err := common.GenerateToken(user.Uuid, user.Username, token.FamilyUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate token failed: %w", err))
+	}
+
+	return &dto.LoginResponseData{
+		Token:           accessToken,
+		ExpireAt:        expireTime,
+		RefreshToken:    refreshToken,
+		RefreshExpireAt: token.ExpiresAt.Unix(),
+	}, nil
+}
+
+// revokeReused 吊销重复使用的 token 所在的会话，该次登录签发的所有 Token 都会失效
+func (this *SessionService) revokeReused(token *model.RefreshToken) *common.ServiceError {
+	log.Logger.Warn(
+		"检测到 Refresh Token 重复使用",
+		log.String("userUuid", token.UserUuid),
+		log.String("familyUuid", token.FamilyUuid),
+	)
+
+	if err := this.revokeSessions(token.UserUuid, []string{token.FamilyUuid}); err != nil {
+		return err
+	}
+
+	return common.ErrRefreshTokenReused
+}
+
+// revokeSessions 吊销会话及其 Refresh Token，并断开这些会话的 WebSocket 连接
+func (this *SessionService) revokeSessions(userUuid string, sessionUuids []string) *common.ServiceError {
+	if len(sessionUuids) == 0 {
+		return nil
+	}
+
+	if err := repository.SessionRepo.Revoke(sessionUuids); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo revoke sessions failed: %w", err))
+	}
+
+	for _, sessionUuid := range sessionUuids {
+		ws.ClientHub.DisconnectSession(userUuid, sessionUuid, sessionRevokedReason)
+	}
+
+	return nil
+}
+
+// runPruner 定期删除已过期的会话、Refresh Token，以及 Token 已过期的吊销记录
+func (this *SessionService) runPruner() {
+	ticker := time.NewTicker(sessionPruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+
+		if err := repository.SessionRepo.DeleteExpired(now); err != nil {
+			log.Logger.Error("清理过期会话失败", log.Any("err", err))
+		}
+
+		if err := repository.RefreshTokenRepo.DeleteExpired(now); err != nil {
+			log.Logger.Error("清理过期 Refresh Token 失败", log.Any("err", err))
+		}
+
+		if err := this.revocationStore().Prune(now); err != nil {
+			log.Logger.Error("清理过期吊销记录失败", log.Any("err", err))
+		}
+	}
+}
+
+// revocationStore 按 jwt.revocationStore 配置创建吊销记录的存储，默认使用数据库
+func (this *SessionService) revocationStore() repository.TokenRevocationStore {
+	this.revocationsOnce.Do(func() {
+		if config.GetConfig().Jwt.RevocationStore == revocationStoreMemory {
+			this.revocations = repository.NewMemoryTokenRevocationStore()
+		} else {
+			this.revocations = &repository.DBTokenRevocationStore{}
+		}
+	})
+
+	return this.revocations
+}
+
+func refreshTokenTtl() time.Duration {
+	days := config.GetConfig().Jwt.RefreshTokenTtl
+	if days <= 0 {
+		return defaultRefreshTokenTtl
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+var SessionSvc = &SessionService{}
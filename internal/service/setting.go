@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/pkg/common"
+)
+
+type SettingService struct {
+}
+
+func (this *SettingService) GetSettings(userUuid string) (*dto.UserSettingData, *common.ServiceError) {
+	setting, err := repository.SettingRepo.FindByUser(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find setting by user failed: %w", err))
+	}
+
+	return toUserSettingData(setting), nil
+}
+
+func (this *SettingService) ModifySettings(
+	userUuid string,
+	req dto.ModifyUserSettingRequest,
+) (*dto.UserSettingData, *common.ServiceError) {
+	updates := map[string]any{}
+	if req.ReadReceipts != nil {
+		updates["read_receipts"] = *req.ReadReceipts
+	}
+	if req.Searchable != nil {
+		updates["searchable"] = *req.Searchable
+	}
+	if req.Discoverable != nil {
+		updates["discoverable"] = *req.Discoverable
+	}
+	if req.EmailVisibility != nil {
+		updates["email_visibility"] = *req.EmailVisibility
+	}
+	if req.AvatarVisibility != nil {
+		updates["avatar_visibility"] = *req.AvatarVisibility
+	}
+	if req.LastSeenVisibility != nil {
+		updates["last_seen_visibility"] = *req.LastSeenVisibility
+	}
+	if req.BioVisibility != nil {
+		updates["bio_visibility"] = *req.BioVisibility
+	}
+
+	setting, err := repository.SettingRepo.UpdatesByUser(userUuid, updates)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo updates setting by user failed: %w", err))
+	}
+
+	return toUserSettingData(setting), nil
+}
+
+func toUserSettingData(setting *model.UserSetting) *dto.UserSettingData {
+	return &dto.UserSettingData{
+		ReadReceipts:       setting.ReadReceipts,
+		Searchable:         setting.Searchable,
+		Discoverable:       setting.Discoverable,
+		EmailVisibility:    setting.EmailVisibility,
+		AvatarVisibility:   setting.AvatarVisibility,
+		LastSeenVisibility: setting.LastSeenVisibility,
+		BioVisibility:      setting.BioVisibility,
+	}
+}
+
+var SettingSvc = &SettingService{}
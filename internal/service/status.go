@@ -0,0 +1,62 @@
+package service
+
+import (
+	"time"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+// 检查自定义状态是否过期的间隔
+const statusExpireInterval = time.Minute
+
+// StatusService 负责推送用户自定义状态的变更，并清除过期的状态
+type StatusService struct {
+}
+
+// notifyContacts 将自定义状态变更推送给用户的联系人，status 为 nil 表示状态已清除
+func (this *StatusService) notifyContacts(userUuid string, status *dto.UserStatusData) {
+	contactUuids, err := PresenceSvc.contactUuids(userUuid)
+
+	if err != nil {
+		log.Logger.Error("查询联系人失败", log.String("userUuid", userUuid), log.Any("err", err))
+		return
+	}
+
+	event := ws.Event{
+		Type: ws.EventUserStatusChanged,
+		Data: dto.UserStatusChangedData{UserUuid: userUuid, Status: status},
+	}
+
+	// 同步给本人的其他设备
+	ws.ClientHub.SendToUsers(append(contactUuids, userUuid), event)
+}
+
+// runExpirer 定期清除过期的自定义状态，并通知联系人
+func (this *StatusService) runExpirer() {
+	ticker := time.NewTicker(statusExpireInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		userUuids, err := repository.UserRepo.FindExpiredStatusUuids(now)
+
+		if err != nil {
+			log.Logger.Error("查询过期状态失败", log.Any("err", err))
+			continue
+		}
+
+		if err := repository.UserRepo.ClearExpiredStatuses(userUuids, now); err != nil {
+			log.Logger.Error("清除过期状态失败", log.Any("err", err))
+			continue
+		}
+
+		for _, userUuid := range userUuids {
+			this.notifyContacts(userUuid, nil)
+		}
+	}
+}
+
+var StatusSvc = &StatusService{}
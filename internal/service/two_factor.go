@@ -0,0 +1,370 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shy-robin/gochat/config"
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/repository"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+const (
+	// 登录时返回的两步验证凭证的有效期
+	loginChallengeTtl = 5 * time.Minute
+	// 同一凭证最多允许校验的次数，用尽后需要重新使用密码登录
+	maxLoginChallengeAttempts = 5
+	// 同一用户连续校验失败的上限，达到后锁定 twoFactorLockout，不受重新登录影响
+	maxTwoFactorFailures = 5
+	twoFactorLockout     = 15 * time.Minute
+	// 定期清理过期凭证的周期
+	loginChallengePruneInterval = time.Hour
+	// 未配置 appName 时验证器 App 中显示的签发者
+	defaultTotpIssuer = "gochat"
+)
+
+// TwoFactorService 管理基于 TOTP 的两步验证
+// 开启后使用密码登录只会得到临时凭证，需要再使用验证码或恢复码换取正式的 Token
+type TwoFactorService struct {
+}
+
+// Status 查询两步验证是否已开启，以及剩余可用的恢复码数量
+func (this *TwoFactorService) Status(userUuid string) (*dto.TwoFactorStatusData, *common.ServiceError) {
+	twoFactor, err := repository.TwoFactorRepo.FindByUser(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find two factor failed: %w", err))
+	}
+
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return &dto.TwoFactorStatusData{Enabled: false}, nil
+	}
+
+	remaining, err := repository.TwoFactorRepo.CountUnusedRecoveryCodes(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo count recovery codes failed: %w", err))
+	}
+
+	return &dto.TwoFactorStatusData{Enabled: true, RecoveryCodesRemaining: remaining}, nil
+}
+
+// Enroll 生成新的 TOTP 密钥，需要调用 Confirm 确认后才会开启两步验证
+func (this *TwoFactorService) Enroll(userUuid string) (*dto.TwoFactorEnrollData, *common.ServiceError) {
+	user, err := repository.UserRepo.FindByUuid(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if user == nil {
+		return nil, common.ErrUserNotFound
+	}
+
+	twoFactor, err := repository.TwoFactorRepo.FindByUser(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find two factor failed: %w", err))
+	}
+
+	if twoFactor != nil && twoFactor.IsEnabled() {
+		return nil, common.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := common.GenerateTotpSecret()
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate totp secret failed: %w", err))
+	}
+
+	if err := repository.TwoFactorRepo.SavePending(&model.UserTwoFactor{UserUuid: userUuid, Secret: secret}); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo save two factor failed: %w", err))
+	}
+
+	return &dto.TwoFactorEnrollData{
+		Secret:          secret,
+		ProvisioningUri: common.TotpProvisioningUri(totpIssuer(), user.Username, secret),
+	}, nil
+}
+
+// Confirm 使用验证码确认密钥并开启两步验证，返回一次性的恢复码
+func (this *TwoFactorService) Confirm(
+	userUuid string,
+	req dto.ConfirmTwoFactorRequest,
+) (*dto.RecoveryCodesData, *common.ServiceError) {
+	twoFactor, err := repository.TwoFactorRepo.FindByUser(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find two factor failed: %w", err))
+	}
+
+	if twoFactor == nil {
+		return nil, common.ErrTwoFactorNotEnrolled
+	}
+
+	if twoFactor.IsEnabled() {
+		return nil, common.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := common.ValidateTotp(twoFactor.Secret, req.Code, time.Now())
+
+	if !ok {
+		return nil, common.ErrTwoFactorCodeInvalid
+	}
+
+	records, codes, err := model.NewRecoveryCodes(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate recovery codes failed: %w", err))
+	}
+
+	enabled, err := repository.TwoFactorRepo.Enable(twoFactor, step, records)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo enable two factor failed: %w", err))
+	}
+
+	if !enabled {
+		return nil, common.ErrTwoFactorAlreadyEnabled
+	}
+
+	return &dto.RecoveryCodesData{RecoveryCodes: codes}, nil
+}
+
+// Disable 关闭两步验证，需要同时校验当前密码和验证码（或恢复码）
+func (this *TwoFactorService) Disable(userUuid string, req dto.DisableTwoFactorRequest) *common.ServiceError {
+	user, err := repository.UserRepo.FindByUuid(userUuid)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if user == nil {
+		return common.ErrUserNotFound
+	}
+
+	if !user.CheckPassword(req.Password) {
+		return common.ErrWrongPassword
+	}
+
+	twoFactor, serviceErr := this.findEnabled(userUuid)
+
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	if twoFactor == nil {
+		return common.ErrTwoFactorNotEnabled
+	}
+
+	if err := this.verifyCode(twoFactor, req.Code); err != nil {
+		return err
+	}
+
+	if err := repository.TwoFactorRepo.Delete(userUuid); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo delete two factor failed: %w", err))
+	}
+
+	return nil
+}
+
+// Verify 使用登录时返回的凭证和验证码（或恢复码）换取正式的 Token
+func (this *TwoFactorService) Verify(
+	req dto.VerifyTwoFactorRequest,
+	userAgent string,
+	ip string,
+) (*dto.LoginResponseData, *common.ServiceError) {
+	challenge, err := repository.LoginChallengeRepo.FindByHash(model.HashLoginChallenge(req.ChallengeToken))
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find login challenge failed: %w", err))
+	}
+
+	if challenge == nil || !challenge.ExpiresAt.After(time.Now()) {
+		return nil, common.ErrInvalidLoginChallenge
+	}
+
+	twoFactor, serviceErr := this.findEnabled(challenge.UserUuid)
+
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	// 登录后两步验证被关闭，凭证随之失效
+	if twoFactor == nil {
+		return nil, common.ErrInvalidLoginChallenge
+	}
+
+	// 先占用一次验证次数再校验验证码，保证并发请求也不会超过上限
+	reserved, err := repository.LoginChallengeRepo.ReserveAttempt(challenge.ID, maxLoginChallengeAttempts)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo reserve login challenge attempt failed: %w", err))
+	}
+
+	if !reserved {
+		if _, err := repository.LoginChallengeRepo.Consume(challenge.ID); err != nil {
+			return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo consume login challenge failed: %w", err))
+		}
+		return nil, common.ErrInvalidLoginChallenge
+	}
+
+	if err := this.verifyCode(twoFactor, req.Code); err != nil {
+		return nil, err
+	}
+
+	consumed, err := repository.LoginChallengeRepo.Consume(challenge.ID)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo consume login challenge failed: %w", err))
+	}
+
+	if !consumed {
+		return nil, common.ErrInvalidLoginChallenge
+	}
+
+	user, err := repository.UserRepo.FindByUuid(challenge.UserUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+	}
+
+	if user == nil {
+		return nil, common.ErrInvalidLoginChallenge
+	}
+
+	return SessionSvc.issue(user, challenge.DeviceName, userAgent, ip)
+}
+
+// isEnabled 判断用户是否已开启两步验证
+func (this *TwoFactorService) isEnabled(userUuid string) (bool, *common.ServiceError) {
+	twoFactor, err := this.findEnabled(userUuid)
+
+	return twoFactor != nil, err
+}
+
+// challenge 为已通过密码校验的用户签发两步验证凭证
+func (this *TwoFactorService) challenge(user *model.User, deviceName string) (*dto.LoginResponseData, *common.ServiceError) {
+	challenge, token, err := model.NewLoginChallenge(user.Uuid, deviceName, loginChallengeTtl)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate login challenge failed: %w", err))
+	}
+
+	if err := repository.LoginChallengeRepo.Create(challenge); err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo create login challenge failed: %w", err))
+	}
+
+	return &dto.LoginResponseData{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ChallengeExpireAt: challenge.ExpiresAt.Unix(),
+	}, nil
+}
+
+// findEnabled 查询用户已开启的两步验证，未开启时返回 nil
+func (this *TwoFactorService) findEnabled(userUuid string) (*model.UserTwoFactor, *common.ServiceError) {
+	twoFactor, err := repository.TwoFactorRepo.FindByUser(userUuid)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find two factor failed: %w", err))
+	}
+
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return nil, nil
+	}
+
+	return twoFactor, nil
+}
+
+// verifyCode 校验验证码或恢复码，同一用户连续失败达到上限后锁定一段时间
+// 失败次数按用户计算，重新使用密码登录获得新的凭证也不会重置
+func (this *TwoFactorService) verifyCode(twoFactor *model.UserTwoFactor, code string) *common.ServiceError {
+	now := time.Now()
+	reserved, err := repository.TwoFactorRepo.ReserveAttempt(twoFactor.UserUuid, maxTwoFactorFailures, now)
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo reserve two factor attempt failed: %w", err))
+	}
+
+	if !reserved {
+		return common.ErrTwoFactorLocked
+	}
+
+	if err := this.checkCode(twoFactor, code); err != nil {
+		if err != common.ErrTwoFactorCodeInvalid {
+			return err
+		}
+
+		locked, lockErr := repository.TwoFactorRepo.LockIfExceeded(twoFactor.UserUuid, maxTwoFactorFailures, now.Add(twoFactorLockout))
+
+		if lockErr != nil {
+			return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo lock two factor failed: %w", lockErr))
+		}
+
+		if locked {
+			log.Logger.Warn("两步验证连续失败次数过多，已锁定", log.String("userUuid", twoFactor.UserUuid))
+		}
+
+		return err
+	}
+
+	if err := repository.TwoFactorRepo.ResetAttempts(twoFactor.UserUuid); err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo reset two factor attempts failed: %w", err))
+	}
+
+	return nil
+}
+
+// checkCode 校验验证码或恢复码，验证码和恢复码都只能使用一次
+func (this *TwoFactorService) checkCode(twoFactor *model.UserTwoFactor, code string) *common.ServiceError {
+	if step, ok := common.ValidateTotp(twoFactor.Secret, code, time.Now()); ok {
+		used, err := repository.TwoFactorRepo.UseStep(twoFactor.UserUuid, step)
+
+		if err != nil {
+			return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo use totp step failed: %w", err))
+		}
+
+		if !used {
+			return common.ErrTwoFactorCodeInvalid
+		}
+
+		return nil
+	}
+
+	used, err := repository.TwoFactorRepo.UseRecoveryCode(twoFactor.UserUuid, model.HashRecoveryCode(code))
+
+	if err != nil {
+		return common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo use recovery code failed: %w", err))
+	}
+
+	if !used {
+		return common.ErrTwoFactorCodeInvalid
+	}
+
+	return nil
+}
+
+// runPruner 定期删除已过期的两步验证凭证
+func (this *TwoFactorService) runPruner() {
+	ticker := time.NewTicker(loginChallengePruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := repository.LoginChallengeRepo.DeleteExpired(time.Now()); err != nil {
+			log.Logger.Error("清理过期两步验证凭证失败", log.Any("err", err))
+		}
+	}
+}
+
+func totpIssuer() string {
+	if appName := config.GetConfig().AppName; appName != "" {
+		return appName
+	}
+	return defaultTotpIssuer
+}
+
+var TwoFactorSvc = &TwoFactorService{}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shy-robin/gochat/internal/handler/v1/dto"
+	"github.com/shy-robin/gochat/internal/model"
+	"github.com/shy-robin/gochat/internal/ws"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+const (
+	// 超过该时间没有收到 typing.start，视为停止输入（客户端断开或崩溃）
+	typingTimeout = 10 * time.Second
+	// 同一用户在同一会话中的 typing.start 最多每隔该时间转发一次
+	// 客户端在输入期间应以大于该间隔的频率重复发送 typing.start 续期
+	typingThrottle = 3 * time.Second
+	// 清理空闲输入状态的周期
+	typingPruneInterval = time.Minute
+)
+
+type typingKey struct {
+	conversationUuid string
+	userUuid         string
+}
+
+type typingState struct {
+	// 最近一次处理 typing.start 的时间，用于节流
+	lastStartAt time.Time
+	// 正在输入时不为空，到期后推送 typing.stopped
+	timer        *time.Timer
+	conversation *model.Conversation
+}
+
+// TypingService 转发会话中的输入状态，输入状态只保存在内存中
+type TypingService struct {
+	mu     sync.Mutex
+	states map[typingKey]*typingState
+}
+
+// Start 处理 typing.start 事件
+func (this *TypingService) Start(client *ws.Client, req dto.TypingRequest) *common.ServiceError {
+	key := typingKey{conversationUuid: req.ConversationUuid, userUuid: client.UserId}
+	now := time.Now()
+
+	this.mu.Lock()
+	if this.throttled(key, now) {
+		this.mu.Unlock()
+		return nil
+	}
+	this.mu.Unlock()
+
+	conversation, serviceErr := ConversationSvc.findConversationForUser(req.ConversationUuid, client.UserId)
+
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	// 单聊中存在屏蔽关系时不转发输入状态
+	if conversation.Type == model.ConversationTypeDirect {
+		peerUuid := conversation.UserAUuid
+		if peerUuid == client.UserId {
+			peerUuid = conversation.UserBUuid
+		}
+
+		if serviceErr := BlockSvc.ensureNotBlocked(client.UserId, peerUuid); serviceErr != nil {
+			return serviceErr
+		}
+	}
+
+	this.mu.Lock()
+	// 校验期间可能已有并发的 typing.start 转发过
+	if this.throttled(key, now) {
+		this.mu.Unlock()
+		return nil
+	}
+	// 成员身份校验通过后才记录状态，非成员的请求不会占用内存
+	state, ok := this.states[key]
+	if !ok {
+		state = &typingState{}
+		this.states[key] = state
+	}
+	state.lastStartAt = now
+	state.conversation = conversation
+	if state.timer != nil {
+		state.timer.Reset(typingTimeout)
+	} else {
+		state.timer = time.AfterFunc(typingTimeout, func() {
+			this.stop(key)
+		})
+	}
+	this.mu.Unlock()
+
+	this.broadcast(conversation, client.UserId, ws.EventTypingStarted)
+
+	return nil
+}
+
+// throttled 判断是否处于节流期间，节流期间只为正在输入的状态续期，不再转发
+// 只有正在输入时才节流，typing.stop 之后立即重新开始输入仍会转发，调用方需要持有锁
+func (this *TypingService) throttled(key typingKey, now time.Time) bool {
+	state, ok := this.states[key]
+	if !ok || state.timer == nil || now.Sub(state.lastStartAt) >= typingThrottle {
+		return false
+	}
+
+	state.timer.Reset(typingTimeout)
+	return true
+}
+
+// Stop 处理 typing.stop 事件
+func (this *TypingService) Stop(client *ws.Client, req dto.TypingRequest) *common.ServiceError {
+	this.stop(typingKey{conversationUuid: req.ConversationUuid, userUuid: client.UserId})
+	return nil
+}
+
+// stop 结束输入状态并通知会话成员，不在输入状态时忽略
+func (this *TypingService) stop(key typingKey) {
+	this.mu.Lock()
+	state, ok := this.states[key]
+	if !ok || state.timer == nil {
+		this.mu.Unlock()
+		return
+	}
+	state.timer.Stop()
+	state.timer = nil
+	conversation := state.conversation
+	this.mu.Unlock()
+
+	this.broadcast(conversation, key.userUuid, ws.EventTypingStopped)
+}
+
+// broadcast 将输入状态推送给会话中除自己以外的成员
+func (this *TypingService) broadcast(conversation *model.Conversation, userUuid string, eventType string) {
+	participantUuids, err := ConversationSvc.participantUuids(conversation)
+
+	if err != nil {
+		log.Logger.Error("查询会话成员失败", log.String("conversationUuid", conversation.Uuid), log.Any("err", err))
+		return
+	}
+
+	receiverUuids := make([]string, 0, len(participantUuids))
+	for _, participantUuid := range participantUuids {
+		if participantUuid != userUuid {
+			receiverUuids = append(receiverUuids, participantUuid)
+		}
+	}
+
+	ws.ClientHub.SendToUsers(receiverUuids, ws.Event{
+		Type: eventType,
+		Data: dto.TypingData{ConversationUuid: conversation.Uuid, UserUuid: userUuid},
+	})
+}
+
+// runPruner 定期清理已停止输入且超过节流时间的状态
+func (this *TypingService) runPruner() {
+	ticker := time.NewTicker(typingPruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		this.mu.Lock()
+		for key, state := range this.states {
+			if state.timer == nil && time.Since(state.lastStartAt) >= typingThrottle {
+				delete(this.states, key)
+			}
+		}
+		this.mu.Unlock()
+	}
+}
+
+var TypingSvc = &TypingService{states: make(map[typingKey]*typingState)}
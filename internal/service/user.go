@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shy-robin/gochat/internal/db"
 	"github.com/shy-robin/gochat/internal/handler/v1/dto"
@@ -42,6 +43,11 @@ func (this *UserService) Register(user *model.User) (*dto.CreateUserResponseData
 		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo transaction failed: %w", txErr))
 	}
 
+	// 注册时填写的邮箱需要通过验证邮件确认
+	if user.Email != "" {
+		EmailSvc.sendVerificationQuietly(user, user.Email)
+	}
+
 	return &dto.CreateUserResponseData{
 		Username: user.Username,
 		Uuid:     user.Uuid,
@@ -49,7 +55,11 @@ func (this *UserService) Register(user *model.User) (*dto.CreateUserResponseData
 	}, nil
 }
 
-func (this *UserService) Login(params *dto.LoginRequest) (*dto.LoginResponseData, *common.ServiceError) {
+func (this *UserService) Login(
+	params *dto.LoginRequest,
+	userAgent string,
+	ip string,
+) (*dto.LoginResponseData, *common.ServiceError) {
 	existingUser, err := repository.UserRepo.FindByUsername(params.Username)
 
 	if err != nil {
@@ -66,20 +76,24 @@ func (this *UserService) Login(params *dto.LoginRequest) (*dto.LoginResponseData
 		return nil, common.ErrWrongPassword
 	}
 
-	// 生成 Token
-	token, expireTime, tokenErr := common.GenerateToken(existingUser.Uuid, existingUser.Username)
+	// 开启两步验证时只返回临时凭证，验证码校验通过后再创建会话
+	twoFactorEnabled, serviceErr := TwoFactorSvc.isEnabled(existingUser.Uuid)
 
-	if tokenErr != nil {
-		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("generate token failed: %w", tokenErr))
+	if serviceErr != nil {
+		return nil, serviceErr
 	}
 
-	return &dto.LoginResponseData{
-		Token:    token,
-		ExpireAt: expireTime,
-	}, nil
+	if twoFactorEnabled {
+		return TwoFactorSvc.challenge(existingUser, params.DeviceName)
+	}
+
+	// 每次登录都创建新的会话，并签发绑定该会话的 Token
+	return SessionSvc.issue(existingUser, params.DeviceName, userAgent, ip)
 }
 
-func (this *UserService) GetUserInfo(uuid string) (*dto.GetUserInfoData, *common.ServiceError) {
+// GetUserInfo 获取用户信息，viewerUuid 为查看者，未登录时为空
+// 本人可以看到全部资料，其他人只能看到隐私设置允许的字段
+func (this *UserService) GetUserInfo(viewerUuid string, uuid string) (*dto.GetUserInfoData, *common.ServiceError) {
 	user, err := repository.UserRepo.FindByUuid(uuid)
 
 	if err != nil {
@@ -90,19 +104,259 @@ func (this *UserService) GetUserInfo(uuid string) (*dto.GetUserInfoData, *common
 		return nil, common.ErrUserNotFound
 	}
 
-	return &dto.GetUserInfoData{
+	// 被对方屏蔽时只能看到最基本的资料
+	if viewerUuid != "" && viewerUuid != user.Uuid {
+		blocked, err := repository.BlockRepo.IsBlocked(user.Uuid, viewerUuid)
+
+		if err != nil {
+			return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo check block failed: %w", err))
+		}
+
+		if blocked {
+			return &dto.GetUserInfoData{
+				Username:   user.Username,
+				Uuid:       user.Uuid,
+				Friendship: model.FriendshipNone,
+			}, nil
+		}
+	}
+
+	userInfo := &dto.GetUserInfoData{
 		Username: user.Username,
 		Uuid:     user.Uuid,
 		Nickname: user.Nickname,
-		Avatar:   user.Avatar,
-		Email:    user.Email,
-	}, nil
+		Status:   toUserStatusData(user),
+		Timezone: user.Timezone,
+		Presence: PresenceSvc.State(user.Uuid),
+	}
+
+	var lastSeenAt *time.Time
+	if userInfo.Presence == PresenceOffline {
+		lastSeenAt = user.LastSeenAt
+	}
+
+	// 本人可以看到全部资料，其他人按隐私设置过滤
+	if viewerUuid == user.Uuid {
+		userInfo.Avatar = user.Avatar
+		userInfo.Email = user.Email
+		userInfo.EmailVerified = &user.EmailVerified
+		userInfo.PendingEmail = user.PendingEmail
+		userInfo.Bio = user.Bio
+		userInfo.Locale = user.Locale
+		userInfo.LastSeenAt = lastSeenAt
+	} else {
+		setting, err := repository.SettingRepo.FindByUser(user.Uuid)
+
+		if err != nil {
+			return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find setting failed: %w", err))
+		}
+
+		isContact := false
+		if viewerUuid != "" {
+			isContact, err = repository.FriendRepo.IsFriend(viewerUuid, user.Uuid)
+
+			if err != nil {
+				return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo check friendship failed: %w", err))
+			}
+		}
+
+		if model.IsVisible(setting.AvatarVisibility, isContact) {
+			userInfo.Avatar = user.Avatar
+		}
+		if model.IsVisible(setting.EmailVisibility, isContact) {
+			userInfo.Email = user.Email
+		}
+		if model.IsVisible(setting.BioVisibility, isContact) {
+			userInfo.Bio = user.Bio
+		}
+		if model.IsVisible(setting.LastSeenVisibility, isContact) {
+			userInfo.LastSeenAt = lastSeenAt
+		}
+	}
+
+	if viewerUuid != "" {
+		friendship, err := FriendSvc.Friendship(viewerUuid, user.Uuid)
+
+		if err != nil {
+			return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find friendship failed: %w", err))
+		}
+
+		userInfo.Friendship = friendship
+	}
+
+	return userInfo, nil
+}
+
+// SearchUsers 按用户名或昵称前缀搜索用户，结果按注册时间排序
+// 头像与用户详情一样按隐私设置过滤
+func (this *UserService) SearchUsers(
+	viewerUuid string,
+	req dto.SearchUsersRequest,
+) ([]dto.UserSearchData, *common.CursorTotalMeta, *common.ServiceError) {
+	var cursor *common.Cursor
+	if req.Cursor != "" {
+		decoded, err := common.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, nil, common.WrapServiceError(common.ErrCursorInvalid, err)
+		}
+		cursor = decoded
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = dto.DefaultPageSize
+	}
+
+	// 多查一条用于判断是否还有更多数据
+	users, total, err := repository.UserRepo.Search(viewerUuid, req.Q, cursor, limit+1)
+
+	if err != nil {
+		return nil, nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo search users failed: %w", err))
+	}
+
+	meta := &common.CursorTotalMeta{Total: total}
+	meta.HasMore = len(users) > limit
+	if meta.HasMore {
+		users = users[:limit]
+		last := users[len(users)-1]
+		meta.NextCursor = common.EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	avatars, svcErr := this.visibleAvatars(viewerUuid, users)
+
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	list := make([]dto.UserSearchData, 0, len(users))
+	for _, user := range users {
+		list = append(list, dto.UserSearchData{
+			Uuid:     user.Uuid,
+			Username: user.Username,
+			Nickname: user.Nickname,
+			Avatar:   avatars[user.Uuid],
+		})
+	}
+
+	return list, meta, nil
 }
 
+// visibleAvatars 按隐私设置过滤一组用户的头像，返回查看者可见的头像，key 为用户 uuid
+// 用户设置和好友关系按整组批量查询，避免逐个用户查询
+func (this *UserService) visibleAvatars(viewerUuid string, users []model.User) (map[string]string, *common.ServiceError) {
+	avatars := make(map[string]string, len(users))
+	otherUuids := make([]string, 0, len(users))
+
+	for _, user := range users {
+		// 本人可以看到自己的头像
+		if user.Uuid == viewerUuid {
+			avatars[user.Uuid] = user.Avatar
+			continue
+		}
+		otherUuids = append(otherUuids, user.Uuid)
+	}
+
+	if len(otherUuids) == 0 {
+		return avatars, nil
+	}
+
+	settings, err := repository.SettingRepo.FindByUsers(otherUuids)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find settings failed: %w", err))
+	}
+
+	friendUuids, err := repository.FriendRepo.FindFriendUuidsIn(viewerUuid, otherUuids)
+
+	if err != nil {
+		return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find friend uuids failed: %w", err))
+	}
+
+	friendSet := make(map[string]struct{}, len(friendUuids))
+	for _, friendUuid := range friendUuids {
+		friendSet[friendUuid] = struct{}{}
+	}
+
+	for _, user := range users {
+		setting, ok := settings[user.Uuid]
+		if !ok {
+			continue
+		}
+
+		_, isContact := friendSet[user.Uuid]
+		if model.IsVisible(setting.AvatarVisibility, isContact) {
+			avatars[user.Uuid] = user.Avatar
+		}
+	}
+
+	return avatars, nil
+}
+
+// ModifyUserInfo 修改当前用户信息，自定义状态变更会实时推送给联系人
+// 修改邮箱时只记录为待验证的新邮箱，并向新邮箱发送验证邮件，验证通过后才会生效
 func (this *UserService) ModifyUserInfo(
 	uuid string,
-	updates dto.ModifyUserInfoRequest,
+	req dto.ModifyUserInfoRequest,
 ) (*dto.ModifyUserInfoData, *common.ServiceError) {
+	updates := map[string]any{}
+	// 需要发送验证邮件的新邮箱
+	pendingEmail := ""
+	if req.Password != "" {
+		updates["password"] = req.Password
+	}
+	if req.Nickname != "" {
+		updates["nickname"] = req.Nickname
+	}
+	if req.Avatar != "" {
+		updates["avatar"] = req.Avatar
+	}
+	if req.Email != "" {
+		user, err := repository.UserRepo.FindByUuid(uuid)
+
+		if err != nil {
+			return nil, common.WrapServiceError(common.ErrDatabaseFailed, fmt.Errorf("repo find by uuid failed: %w", err))
+		}
+
+		if user == nil {
+			return nil, common.ErrUserNotFound
+		}
+
+		if req.Email == user.Email {
+			// 改回当前邮箱，取消尚未验证的修改
+			updates["pending_email"] = ""
+		} else if req.Email != user.PendingEmail {
+			if err := EmailSvc.ensureCooldown(uuid); err != nil {
+				return nil, err
+			}
+			updates["pending_email"] = req.Email
+			pendingEmail = req.Email
+		}
+	}
+	if req.Bio != nil {
+		updates["bio"] = *req.Bio
+	}
+	if req.Timezone != nil {
+		updates["timezone"] = *req.Timezone
+	}
+	if req.Locale != nil {
+		updates["locale"] = *req.Locale
+	}
+	if req.Status != nil {
+		if req.Status.Text == "" && req.Status.Emoji == "" {
+			updates["status_text"] = ""
+			updates["status_emoji"] = ""
+			updates["status_expires_at"] = nil
+		} else {
+			if req.Status.ExpiresAt != nil && !req.Status.ExpiresAt.After(time.Now()) {
+				return nil, common.ErrStatusExpiresAtInvalid
+			}
+
+			updates["status_text"] = req.Status.Text
+			updates["status_emoji"] = req.Status.Emoji
+			updates["status_expires_at"] = req.Status.ExpiresAt
+		}
+	}
+
 	userInfo, err := repository.UserRepo.UpdatesByUuid(uuid, updates)
 
 	if err != nil {
@@ -113,13 +367,41 @@ func (this *UserService) ModifyUserInfo(
 		return nil, common.ErrUserNotFound
 	}
 
+	status := toUserStatusData(userInfo)
+
+	if req.Status != nil {
+		StatusSvc.notifyContacts(userInfo.Uuid, status)
+	}
+
+	if pendingEmail != "" {
+		EmailSvc.sendVerificationQuietly(userInfo, pendingEmail)
+	}
+
 	return &dto.ModifyUserInfoData{
-		Nickname: userInfo.Nickname,
-		Avatar:   userInfo.Avatar,
-		Email:    userInfo.Email,
+		Nickname:     userInfo.Nickname,
+		Avatar:       userInfo.Avatar,
+		Email:        userInfo.Email,
+		PendingEmail: userInfo.PendingEmail,
+		Bio:          userInfo.Bio,
+		Status:       status,
+		Timezone:     userInfo.Timezone,
+		Locale:       userInfo.Locale,
 	}, nil
 }
 
+// toUserStatusData 转换用户的自定义状态，未设置或已过期时返回 nil
+func toUserStatusData(user *model.User) *dto.UserStatusData {
+	if !user.HasStatus() {
+		return nil
+	}
+
+	return &dto.UserStatusData{
+		Text:      user.StatusText,
+		Emoji:     user.StatusEmoji,
+		ExpiresAt: user.StatusExpiresAt,
+	}
+}
+
 // 分配内存，初始化零值并返回指针
 // var UserSvc = new(UserService)
 var UserSvc = &UserService{}
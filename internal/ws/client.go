@@ -0,0 +1,215 @@
+package ws
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+// 参考 gorilla/websocket 的 chat 示例
+const (
+	// 写超时时间
+	writeWait = 10 * time.Second
+	// 等待客户端 pong 的超时时间，超时则认为连接已断开
+	pongWait = 60 * time.Second
+	// 发送 ping 的周期，必须小于 pongWait
+	pingPeriod = (pongWait * 9) / 10
+	// 客户端单条消息的最大字节数
+	maxMessageSize = 64 * 1024
+	// 每个连接的发送缓冲区大小
+	sendBufferSize = 256
+)
+
+// TokenProtocol 是通过 Sec-WebSocket-Protocol 传递 Token 时使用的子协议名
+// 客户端示例：new WebSocket(url, ["access_token", token])
+const TokenProtocol = "access_token"
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// 握手成功后回显该子协议，否则浏览器会主动断开连接
+	Subprotocols: []string{TokenProtocol},
+	// 鉴权依赖 Token 而不是 Cookie，因此不存在跨站劫持的问题，允许任意来源
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// Client 表示一个 WebSocket 连接（一个设备）
+type Client struct {
+	Id     string
+	UserId string
+	// 建立连接时使用的 Token 所属的登录会话，会话被吊销时断开该连接
+	SessionId string
+
+	hub  *Hub
+	conn *websocket.Conn
+	send chan []byte
+
+	// 最近一次心跳的时间（UnixNano），以及客户端是否上报了离开状态
+	lastHeartbeatAt atomic.Int64
+	away            atomic.Bool
+}
+
+// Serve 将 HTTP 连接升级为 WebSocket 连接，并注册到 Hub 中
+// 该方法会阻塞直到连接断开
+func (this *Hub) Serve(w http.ResponseWriter, r *http.Request, userId string, sessionId string) error {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &Client{
+		Id:        uuid.NewString(),
+		UserId:    userId,
+		SessionId: sessionId,
+		hub:       this,
+		conn:      conn,
+		send:      make(chan []byte, sendBufferSize),
+	}
+	client.lastHeartbeatAt.Store(time.Now().UnixNano())
+	this.register(client)
+
+	client.Send(Event{
+		Type: EventConnected,
+		Data: ConnectedData{ConnectionId: client.Id},
+	})
+
+	go client.writePump()
+	client.readPump()
+
+	return nil
+}
+
+// Heartbeat 记录客户端心跳，away 表示客户端检测到用户处于空闲状态（如页面切到后台）
+func (this *Client) Heartbeat(away bool) {
+	this.lastHeartbeatAt.Store(time.Now().UnixNano())
+	this.away.Store(away)
+}
+
+// IsAway 判断连接是否处于离开状态：客户端主动上报离开，或超过 timeout 没有心跳
+func (this *Client) IsAway(timeout time.Duration) bool {
+	if this.away.Load() {
+		return true
+	}
+	return time.Since(time.Unix(0, this.lastHeartbeatAt.Load())) > timeout
+}
+
+// Send 推送事件给当前连接
+func (this *Client) Send(event Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Logger.Error("序列化 WebSocket 事件失败", log.Any("err", err))
+		return
+	}
+
+	this.hub.mu.RLock()
+	defer this.hub.mu.RUnlock()
+	this.sendRaw(payload)
+}
+
+// SendError 推送错误事件给当前连接
+func (this *Client) SendError(err *common.ServiceError) {
+	this.Send(Event{
+		Type: EventError,
+		Data: ErrorData{Code: err.Code, Message: err.Message},
+	})
+}
+
+// Close 向客户端发送关闭帧后断开连接，由 readPump 负责注销
+func (this *Client) Close(reason string) {
+	this.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason),
+		time.Now().Add(writeWait),
+	)
+	this.conn.Close()
+}
+
+// sendRaw 非阻塞地写入发送缓冲区，调用方需持有 hub.mu 读锁
+// 缓冲区写满说明客户端消费过慢，直接断开连接，由 readPump 负责注销
+func (this *Client) sendRaw(payload []byte) {
+	if _, ok := this.hub.clients[this.UserId][this]; !ok {
+		// 连接已注销，send 通道已关闭
+		return
+	}
+
+	select {
+	case this.send <- payload:
+	default:
+		log.Logger.Warn("WebSocket 发送缓冲区已满，断开连接",
+			log.String("userId", this.UserId),
+			log.String("connectionId", this.Id),
+		)
+		this.conn.Close()
+	}
+}
+
+// readPump 读取客户端发送的事件，每个连接只有一个读协程
+func (this *Client) readPump() {
+	defer func() {
+		this.hub.unregister(this)
+		this.conn.Close()
+	}()
+
+	this.conn.SetReadLimit(maxMessageSize)
+	this.conn.SetReadDeadline(time.Now().Add(pongWait))
+	this.conn.SetPongHandler(func(string) error {
+		this.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		_, message, err := this.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Logger.Warn("WebSocket 连接异常断开", log.String("connectionId", this.Id), log.Any("err", err))
+			}
+			return
+		}
+
+		var event InboundEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			this.SendError(common.ErrInvalidEvent)
+			continue
+		}
+
+		this.hub.dispatch(this, event)
+	}
+}
+
+// writePump 将发送缓冲区中的事件写入连接，并定期发送 ping，每个连接只有一个写协程
+func (this *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		this.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-this.send:
+			this.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				// Hub 关闭了发送通道
+				this.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+
+			if err := this.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			this.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := this.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package ws
+
+import "encoding/json"
+
+// 服务端推送的事件类型
+const (
+	EventConnected = "connected"
+	EventError     = "error"
+
+	// 新消息
+	EventMessageNew = "message.new"
+	// 消息被编辑、撤回或删除
+	EventMessageEdited  = "message.edited"
+	EventMessageDeleted = "message.deleted"
+	// 群消息被置顶、取消置顶
+	EventMessagePinned   = "message.pinned"
+	EventMessageUnpinned = "message.unpinned"
+	// 消息的表情回应变更
+	EventMessageReaction = "message.reaction"
+	// 话题有新回复，推送给会话中的所有参与者，用于更新回复数
+	// 回复内容本身以 message.new 事件只推送给话题参与者
+	EventThreadUpdated = "thread.updated"
+	// 消息送达、已读状态变更
+	EventMessageReceipt = "message.receipt"
+
+	// 会话中的其他成员开始、停止输入
+	EventTypingStarted = "typing.started"
+	EventTypingStopped = "typing.stopped"
+
+	// 联系人在线状态变更
+	EventPresenceChanged = "presence.changed"
+
+	// 联系人自定义状态变更
+	EventUserStatusChanged = "user.status_changed"
+
+	// 好友申请
+	EventFriendRequestReceived = "friend.request_received"
+	EventFriendRequestUpdated  = "friend.request_updated"
+
+	// 群聊
+	EventGroupCreated        = "group.created"
+	EventGroupUpdated        = "group.updated"
+	EventGroupMembersAdded   = "group.members_added"
+	EventGroupMembersRemoved = "group.members_removed"
+	// 成员角色变更
+	EventGroupMemberRoleChanged = "group.member_role_changed"
+	// 成员被禁言或解除禁言
+	EventGroupMemberMuted = "group.member_muted"
+
+	// 入群申请：新申请推送给拥有 invite_members 权限的成员，审批结果同时推送给申请人
+	EventGroupJoinRequestReceived = "group.join_request_received"
+	EventGroupJoinRequestUpdated  = "group.join_request_updated"
+)
+
+// 客户端发送的事件类型
+const (
+	// 确认消息已送达或已读
+	EventReceiptAck = "receipt.ack"
+	// 心跳，同时上报是否处于离开状态
+	EventHeartbeat = "heartbeat"
+	// 开始、停止输入
+	EventTypingStart = "typing.start"
+	EventTypingStop  = "typing.stop"
+)
+
+// Event 是服务端推送给客户端的事件
+type Event struct {
+	Type string `json:"type" example:"connected"`
+	Data any    `json:"data,omitempty"`
+}
+
+// InboundEvent 是客户端发送给服务端的事件
+// Data 延迟解析，由对应事件类型的 EventHandler 自行反序列化
+type InboundEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// EventHandler 处理客户端发送的某一类事件
+type EventHandler func(client *Client, data json.RawMessage)
+
+type ConnectedData struct {
+	ConnectionId string `json:"connectionId"`
+}
+
+type ErrorData struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
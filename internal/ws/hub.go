@@ -0,0 +1,189 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/shy-robin/gochat/pkg/common"
+	"github.com/shy-robin/gochat/pkg/global/log"
+)
+
+// Hub 维护所有在线的 WebSocket 连接
+// 同一个用户可能同时在多个设备上登录，因此按用户 Uuid 保存该用户的所有连接
+type Hub struct {
+	mu       sync.RWMutex
+	clients  map[string]map[*Client]struct{}
+	handlers map[string]EventHandler
+
+	// 连接建立、断开后的回调，用于在线状态等需要感知连接变化的功能
+	onConnect    []func(client *Client)
+	onDisconnect []func(client *Client)
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		clients:  make(map[string]map[*Client]struct{}),
+		handlers: make(map[string]EventHandler),
+	}
+}
+
+// 全局连接中心
+var ClientHub = NewHub()
+
+// HandleFunc 注册客户端事件的处理函数
+// NOTE: 需要在服务启动前注册，运行期间不支持修改
+func (this *Hub) HandleFunc(eventType string, handler EventHandler) {
+	this.handlers[eventType] = handler
+}
+
+// OnConnect 注册连接建立后的回调
+// NOTE: 需要在服务启动前注册，运行期间不支持修改
+func (this *Hub) OnConnect(fn func(client *Client)) {
+	this.onConnect = append(this.onConnect, fn)
+}
+
+// OnDisconnect 注册连接断开后的回调，回调执行时该连接已从 Hub 中移除
+// NOTE: 需要在服务启动前注册，运行期间不支持修改
+func (this *Hub) OnDisconnect(fn func(client *Client)) {
+	this.onDisconnect = append(this.onDisconnect, fn)
+}
+
+func (this *Hub) register(client *Client) {
+	this.addClient(client)
+
+	for _, fn := range this.onConnect {
+		fn(client)
+	}
+}
+
+func (this *Hub) unregister(client *Client) {
+	if !this.removeClient(client) {
+		return
+	}
+
+	for _, fn := range this.onDisconnect {
+		fn(client)
+	}
+}
+
+func (this *Hub) addClient(client *Client) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	userClients, ok := this.clients[client.UserId]
+	if !ok {
+		userClients = make(map[*Client]struct{})
+		this.clients[client.UserId] = userClients
+	}
+	userClients[client] = struct{}{}
+
+	log.Logger.Info("WebSocket 连接建立",
+		log.String("userId", client.UserId),
+		log.String("connectionId", client.Id),
+		log.Any("连接数", len(userClients)),
+	)
+}
+
+// removeClient 移除连接，返回连接是否存在
+func (this *Hub) removeClient(client *Client) bool {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	userClients, ok := this.clients[client.UserId]
+	if !ok {
+		return false
+	}
+	if _, ok := userClients[client]; !ok {
+		return false
+	}
+
+	delete(userClients, client)
+	close(client.send)
+	if len(userClients) == 0 {
+		delete(this.clients, client.UserId)
+	}
+
+	log.Logger.Info("WebSocket 连接断开",
+		log.String("userId", client.UserId),
+		log.String("connectionId", client.Id),
+		log.Any("连接数", len(userClients)),
+	)
+
+	return true
+}
+
+// dispatch 将客户端事件分发给对应的处理函数
+func (this *Hub) dispatch(client *Client, event InboundEvent) {
+	handler, ok := this.handlers[event.Type]
+	if !ok {
+		client.SendError(common.ErrUnsupportedEvent)
+		return
+	}
+	handler(client, event.Data)
+}
+
+// SendToUser 将事件推送给用户的所有在线设备
+func (this *Hub) SendToUser(userId string, event Event) {
+	this.SendToUsers([]string{userId}, event)
+}
+
+// SendToUsers 将事件推送给多个用户的所有在线设备
+func (this *Hub) SendToUsers(userIds []string, event Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Logger.Error("序列化 WebSocket 事件失败", log.Any("err", err))
+		return
+	}
+
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	for _, userId := range userIds {
+		for client := range this.clients[userId] {
+			client.sendRaw(payload)
+		}
+	}
+}
+
+// IsOnline 判断用户是否有在线的连接
+func (this *Hub) IsOnline(userId string) bool {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	return len(this.clients[userId]) > 0
+}
+
+// UserClients 返回用户当前所有连接的快照
+func (this *Hub) UserClients(userId string) []*Client {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	clients := make([]*Client, 0, len(this.clients[userId]))
+	for client := range this.clients[userId] {
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+// DisconnectSession 断开用户在某个登录会话下建立的所有连接
+func (this *Hub) DisconnectSession(userId string, sessionId string, reason string) {
+	for _, client := range this.UserClients(userId) {
+		if client.SessionId == sessionId {
+			client.Close(reason)
+		}
+	}
+}
+
+// OnlineUserIds 返回当前所有在线用户的 uuid
+func (this *Hub) OnlineUserIds() []string {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+
+	userIds := make([]string, 0, len(this.clients))
+	for userId := range this.clients {
+		userIds = append(userIds, userId)
+	}
+
+	return userIds
+}
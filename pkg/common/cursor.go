@@ -0,0 +1,44 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cursor 是基于 (创建时间, 自增 ID) 的分页游标
+// 仅使用时间戳无法区分同一时刻创建的多条记录，因此追加自增 ID 保证排序稳定
+type Cursor struct {
+	CreatedAt time.Time
+	Id        uint
+}
+
+// cursorPayload 是游标序列化后的内容，对客户端不透明
+type cursorPayload struct {
+	T  int64 `json:"t"`
+	Id uint  `json:"id"`
+}
+
+var ErrCursorMalformed = errors.New("malformed cursor")
+
+// EncodeCursor 将游标编码为 URL 安全的字符串
+func EncodeCursor(createdAt time.Time, id uint) string {
+	payload, _ := json.Marshal(cursorPayload{T: createdAt.UnixNano(), Id: id})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor 解析客户端传入的游标
+func DecodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrCursorMalformed
+	}
+
+	payload := cursorPayload{}
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.Id == 0 {
+		return nil, ErrCursorMalformed
+	}
+
+	return &Cursor{CreatedAt: time.Unix(0, payload.T), Id: payload.Id}, nil
+}
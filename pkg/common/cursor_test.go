@@ -0,0 +1,63 @@
+package common
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cases := []struct {
+		createdAt time.Time
+		id        uint
+	}{
+		{time.Date(2025, 11, 23, 15, 53, 56, 811000000, time.UTC), 1},
+		{time.Date(2025, 11, 23, 15, 53, 56, 811123456, time.UTC), 42},
+		{time.Unix(0, 0), 1 << 40},
+	}
+
+	for _, c := range cases {
+		cursor, err := DecodeCursor(EncodeCursor(c.createdAt, c.id))
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+
+		if !cursor.CreatedAt.Equal(c.createdAt) {
+			t.Errorf("CreatedAt = %v, want %v", cursor.CreatedAt, c.createdAt)
+		}
+		if cursor.Id != c.id {
+			t.Errorf("Id = %d, want %d", cursor.Id, c.id)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsTampering(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	valid := EncodeCursor(time.Now(), 42)
+
+	cases := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"t":1,"id":1}`))},
+		{"truncated", valid[:len(valid)-3]},
+		{"not json", encode("t=1&id=1")},
+		{"missing id", encode(`{"t":1}`)},
+		{"zero id", encode(`{"t":1,"id":0}`)},
+		{"negative id", encode(`{"t":1,"id":-1}`)},
+		{"string id", encode(`{"t":1,"id":"1"}`)},
+		{"string timestamp", encode(`{"t":"2025-11-23","id":1}`)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := DecodeCursor(c.cursor); err != ErrCursorMalformed {
+				t.Errorf("DecodeCursor(%q) error = %v, want %v", c.cursor, err, ErrCursorMalformed)
+			}
+		})
+	}
+}
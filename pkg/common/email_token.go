@@ -0,0 +1,75 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shy-robin/gochat/config"
+)
+
+// 邮箱验证 Token 的 aud，用于和 Access Token 区分
+const emailVerificationAudience = "email_verification"
+
+// EmailVerificationClaims 定义了邮箱验证 Token 的载荷信息
+// jti 为验证记录的 uuid，sub 为用户 uuid
+type EmailVerificationClaims struct {
+	jwt.RegisteredClaims
+	Email string `json:"email"`
+}
+
+// GenerateEmailVerificationToken 生成邮箱验证链接中使用的 Token
+// 签名密钥由 jwt.secret 派生，与 Access Token 的密钥不同，因此两者不能互相冒用
+func GenerateEmailVerificationToken(id string, userId string, email string, ttl time.Duration) (string, error) {
+	now := time.Now()
+
+	claims := &EmailVerificationClaims{
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Subject:   userId,
+			Audience:  jwt.ClaimStrings{emailVerificationAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "gochat-api-service",
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(emailVerificationKey())
+}
+
+// ValidateEmailVerificationToken 验证邮箱验证 Token 的签名、有效期和用途，是否已被使用需要由调用方另外检查
+func ValidateEmailVerificationToken(tokenString string) (*EmailVerificationClaims, error) {
+	claims := &EmailVerificationClaims{}
+
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		claims,
+		func(token *jwt.Token) (any, error) {
+			return emailVerificationKey(), nil
+		},
+		jwt.WithAudience(emailVerificationAudience),
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.ID == "" {
+		return nil, errors.New("invalid email verification token")
+	}
+
+	return claims, nil
+}
+
+// emailVerificationKey 派生邮箱验证 Token 的签名密钥：HMAC-SHA256(jwt.secret, aud)
+func emailVerificationKey() []byte {
+	mac := hmac.New(sha256.New, []byte(config.GetConfig().Jwt.Secret))
+	mac.Write([]byte(emailVerificationAudience))
+	return mac.Sum(nil)
+}
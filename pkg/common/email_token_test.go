@@ -0,0 +1,87 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestEmailVerificationTokenRoundTrip(t *testing.T) {
+	token, err := GenerateEmailVerificationToken("verification-uuid", "user-uuid", "robin@example.com", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateEmailVerificationToken() error = %v", err)
+	}
+
+	claims, err := ValidateEmailVerificationToken(token)
+	if err != nil {
+		t.Fatalf("ValidateEmailVerificationToken() error = %v", err)
+	}
+
+	if claims.ID != "verification-uuid" {
+		t.Errorf("ID = %q, want %q", claims.ID, "verification-uuid")
+	}
+	if claims.Subject != "user-uuid" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "user-uuid")
+	}
+	if claims.Email != "robin@example.com" {
+		t.Errorf("Email = %q, want %q", claims.Email, "robin@example.com")
+	}
+}
+
+func TestValidateEmailVerificationTokenRejectsInvalidTokens(t *testing.T) {
+	sign := func(claims jwt.Claims, key []byte) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+		return token
+	}
+	claimsWith := func(modify func(*EmailVerificationClaims)) *EmailVerificationClaims {
+		claims := &EmailVerificationClaims{
+			Email: "robin@example.com",
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "verification-uuid",
+				Subject:   "user-uuid",
+				Audience:  jwt.ClaimStrings{emailVerificationAudience},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+		modify(claims)
+		return claims
+	}
+
+	valid, err := GenerateEmailVerificationToken("verification-uuid", "user-uuid", "robin@example.com", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateEmailVerificationToken() error = %v", err)
+	}
+	parts := strings.Split(valid, ".")
+
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"malformed", "not-a-token"},
+		{"tampered signature", parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2]))},
+		{"expired", sign(claimsWith(func(c *EmailVerificationClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		}), emailVerificationKey())},
+		{"wrong audience", sign(claimsWith(func(c *EmailVerificationClaims) {
+			c.Audience = jwt.ClaimStrings{"access"}
+		}), emailVerificationKey())},
+		{"missing id", sign(claimsWith(func(c *EmailVerificationClaims) {
+			c.ID = ""
+		}), emailVerificationKey())},
+		{"signed with another key", sign(claimsWith(func(c *EmailVerificationClaims) {}), []byte("another-secret"))},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := ValidateEmailVerificationToken(c.token); err == nil {
+				t.Errorf("ValidateEmailVerificationToken() error = nil, want error")
+			}
+		})
+	}
+}
@@ -81,6 +81,104 @@ var (
 		HTTPStatus: http.StatusUnauthorized,
 	}
 
+	ErrGroupPermissionDenied = &ServiceError{
+		Code:       20007,
+		Status:     "error",
+		Message:    "没有权限执行该操作",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrNotGroupMember = &ServiceError{
+		Code:       20008,
+		Status:     "error",
+		Message:    "你不是该群成员",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrNotConversationMember = &ServiceError{
+		Code:       20009,
+		Status:     "error",
+		Message:    "你不是该会话的参与者",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrReceiptsForbidden = &ServiceError{
+		Code:       20010,
+		Status:     "error",
+		Message:    "只有消息发送者可以查看回执",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrMessagePermissionDenied = &ServiceError{
+		Code:       20011,
+		Status:     "error",
+		Message:    "只能编辑或撤回自己发送的消息",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrMessageEditExpired = &ServiceError{
+		Code:       20012,
+		Status:     "error",
+		Message:    "消息已超过可编辑时间",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrFriendRequestPermissionDenied = &ServiceError{
+		Code:       20013,
+		Status:     "error",
+		Message:    "无权处理该好友申请",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrBlocked = &ServiceError{
+		Code:       20014,
+		Status:     "error",
+		Message:    "由于屏蔽关系，无法进行该操作",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrGroupMemberMuted = &ServiceError{
+		Code:       20015,
+		Status:     "error",
+		Message:    "你已被禁言，禁言结束前不能发送消息",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrGroupUserBanned = &ServiceError{
+		Code:       20016,
+		Status:     "error",
+		Message:    "用户已被禁止加入该群",
+		HTTPStatus: http.StatusForbidden,
+	}
+
+	ErrInvalidRefreshToken = &ServiceError{
+		Code:       20017,
+		Status:     "error",
+		Message:    "Refresh Token无效或已过期",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
+	ErrRefreshTokenReused = &ServiceError{
+		Code:       20018,
+		Status:     "error",
+		Message:    "Refresh Token已被使用，当前登录已失效，请重新登录",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
+	ErrTokenRevoked = &ServiceError{
+		Code:       20019,
+		Status:     "error",
+		Message:    "Token已失效，请重新登录",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
+	ErrInvalidLoginChallenge = &ServiceError{
+		Code:       20020,
+		Status:     "error",
+		Message:    "两步验证已过期或失败次数过多，请重新登录",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+
 	// 404 Not Found
 	ErrUserNotFound = &ServiceError{
 		Code:       30001,
@@ -173,6 +271,363 @@ var (
 		HTTPStatus: http.StatusBadRequest,
 	}
 
+	ErrInvalidEvent = &ServiceError{
+		Code:       30014,
+		Status:     "error",
+		Message:    "事件格式不正确",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrUnsupportedEvent = &ServiceError{
+		Code:       30015,
+		Status:     "error",
+		Message:    "不支持的事件类型",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMessageContentEmpty = &ServiceError{
+		Code:       30016,
+		Status:     "error",
+		Message:    "消息内容不能为空",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMessageContentTooLong = &ServiceError{
+		Code:       30017,
+		Status:     "error",
+		Message:    "消息内容不能超过5000个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMessageTypeInvalid = &ServiceError{
+		Code:       30018,
+		Status:     "error",
+		Message:    "消息类型不正确",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrLimitInvalid = &ServiceError{
+		Code:       30019,
+		Status:     "error",
+		Message:    "limit 必须在 1 到 100 之间",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMessageSelf = &ServiceError{
+		Code:       30020,
+		Status:     "error",
+		Message:    "不能给自己发送消息",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupNameEmpty = &ServiceError{
+		Code:       30021,
+		Status:     "error",
+		Message:    "群名称不能为空",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupNameTooLong = &ServiceError{
+		Code:       30022,
+		Status:     "error",
+		Message:    "群名称不能超过50个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupMembersEmpty = &ServiceError{
+		Code:       30023,
+		Status:     "error",
+		Message:    "成员列表不能为空",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupMembersTooMany = &ServiceError{
+		Code:       30024,
+		Status:     "error",
+		Message:    "单次最多添加100个成员",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrPageInvalid = &ServiceError{
+		Code:       30025,
+		Status:     "error",
+		Message:    "page 必须大于等于 1",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrPageSizeInvalid = &ServiceError{
+		Code:       30026,
+		Status:     "error",
+		Message:    "pageSize 必须在 1 到 100 之间",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrCursorConflict = &ServiceError{
+		Code:       30027,
+		Status:     "error",
+		Message:    "before 和 after 不能同时传入",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrCursorInvalid = &ServiceError{
+		Code:       30028,
+		Status:     "error",
+		Message:    "游标格式不正确",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMessageUuidEmpty = &ServiceError{
+		Code:       30029,
+		Status:     "error",
+		Message:    "消息 uuid 不能为空",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrReceiptStatusInvalid = &ServiceError{
+		Code:       30030,
+		Status:     "error",
+		Message:    "回执状态只能是 delivered 或 read",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrConversationUuidEmpty = &ServiceError{
+		Code:       30031,
+		Status:     "error",
+		Message:    "会话 uuid 不能为空",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMessageNotEditable = &ServiceError{
+		Code:       30032,
+		Status:     "error",
+		Message:    "只能编辑文本消息",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrDeleteScopeInvalid = &ServiceError{
+		Code:       30033,
+		Status:     "error",
+		Message:    "删除范围只能是 me 或 everyone",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrEmojiInvalid = &ServiceError{
+		Code:       30034,
+		Status:     "error",
+		Message:    "表情不能为空，且长度不能超过 16 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrReactionLimitExceeded = &ServiceError{
+		Code:       30035,
+		Status:     "error",
+		Message:    "该消息的表情种类已达上限",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrThreadNested = &ServiceError{
+		Code:       30036,
+		Status:     "error",
+		Message:    "不能对话题中的回复再创建话题",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrFriendRequestSelf = &ServiceError{
+		Code:       30037,
+		Status:     "error",
+		Message:    "不能添加自己为好友",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrReceiverUuidEmpty = &ServiceError{
+		Code:       30038,
+		Status:     "error",
+		Message:    "接收者 uuid 不能为空",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrFriendRequestMessageTooLong = &ServiceError{
+		Code:       30039,
+		Status:     "error",
+		Message:    "附言不能超过 200 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrDirectionInvalid = &ServiceError{
+		Code:       30040,
+		Status:     "error",
+		Message:    "direction 只能是 incoming 或 outgoing",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrBlockSelf = &ServiceError{
+		Code:       30041,
+		Status:     "error",
+		Message:    "不能屏蔽自己",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrSearchQueryInvalid = &ServiceError{
+		Code:       30042,
+		Status:     "error",
+		Message:    "搜索关键字不能为空，且长度不能超过 20 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrVisibilityInvalid = &ServiceError{
+		Code:       30043,
+		Status:     "error",
+		Message:    "可见范围只能是 everyone、contacts 或 nobody",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrBioTooLong = &ServiceError{
+		Code:       30044,
+		Status:     "error",
+		Message:    "个人简介长度不能超过 200 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrStatusTextTooLong = &ServiceError{
+		Code:       30045,
+		Status:     "error",
+		Message:    "状态文字长度不能超过 100 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrStatusExpiresAtInvalid = &ServiceError{
+		Code:       30046,
+		Status:     "error",
+		Message:    "状态过期时间必须晚于当前时间",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrTimezoneInvalid = &ServiceError{
+		Code:       30047,
+		Status:     "error",
+		Message:    "时区不合法，请使用 IANA 时区名称，如 Asia/Shanghai",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrLocaleInvalid = &ServiceError{
+		Code:       30048,
+		Status:     "error",
+		Message:    "语言不合法，请使用 BCP 47 语言标签，如 zh-CN",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrEmailHashesEmpty = &ServiceError{
+		Code:       30049,
+		Status:     "error",
+		Message:    "邮箱哈希列表不能为空",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrEmailHashInvalid = &ServiceError{
+		Code:       30050,
+		Status:     "error",
+		Message:    "邮箱哈希必须是 64 位十六进制字符串",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrEmailHashesTooMany = &ServiceError{
+		Code:       30051,
+		Status:     "error",
+		Message:    "单次上传的邮箱哈希数量超过上限",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrInviteMaxUsesInvalid = &ServiceError{
+		Code:       30052,
+		Status:     "error",
+		Message:    "邀请链接的使用次数必须在 1 到 10000 之间",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrInviteExpiresAtInvalid = &ServiceError{
+		Code:       30053,
+		Status:     "error",
+		Message:    "邀请链接的过期时间必须晚于当前时间",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrJoinRequestUuidsInvalid = &ServiceError{
+		Code:       30054,
+		Status:     "error",
+		Message:    "入群申请列表不能为空，且不能超过 100 条",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrReviewActionInvalid = &ServiceError{
+		Code:       30055,
+		Status:     "error",
+		Message:    "审批操作只能是 approve 或 reject",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupNotPrivate = &ServiceError{
+		Code:       30056,
+		Status:     "error",
+		Message:    "该群不接受入群申请",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupRoleNameInvalid = &ServiceError{
+		Code:       30057,
+		Status:     "error",
+		Message:    "角色名称不能为空，且长度不能超过 20 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupPermissionInvalid = &ServiceError{
+		Code:       30058,
+		Status:     "error",
+		Message:    "权限只能是 send_message、send_media、pin_message、invite_members、remove_members、edit_group、ban_members、mute_members",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrGroupRoleReserved = &ServiceError{
+		Code:       30059,
+		Status:     "error",
+		Message:    "内置角色不能创建、修改或删除",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrModerationReasonInvalid = &ServiceError{
+		Code:       30060,
+		Status:     "error",
+		Message:    "原因不能超过 200 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMuteDurationInvalid = &ServiceError{
+		Code:       30061,
+		Status:     "error",
+		Message:    "禁言时长必须在 60 秒到 30 天之间",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrDeviceNameTooLong = &ServiceError{
+		Code:       30062,
+		Status:     "error",
+		Message:    "设备名称不能超过 100 个字符",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrTwoFactorCodeInvalid = &ServiceError{
+		Code:       30063,
+		Status:     "error",
+		Message:    "验证码或恢复码错误",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrEmailVerificationInvalid = &ServiceError{
+		Code:       30064,
+		Status:     "error",
+		Message:    "验证链接无效或已过期",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
 	// 409 Conflict
 	ErrUsernameConflict = &ServiceError{
 		Code:       40001,
@@ -181,6 +636,210 @@ var (
 		HTTPStatus: http.StatusConflict,
 	}
 
+	ErrAlreadyFriends = &ServiceError{
+		Code:       40009,
+		Status:     "error",
+		Message:    "你们已经是好友了",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrFriendRequestPending = &ServiceError{
+		Code:       40010,
+		Status:     "error",
+		Message:    "已发送过好友申请，请等待对方处理",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrFriendRequestNotPending = &ServiceError{
+		Code:       40012,
+		Status:     "error",
+		Message:    "好友申请已处理或已过期",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	// 404 Not Found
+	ErrReceiverNotFound = &ServiceError{
+		Code:       40002,
+		Status:     "error",
+		Message:    "接收者不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrGroupNotFound = &ServiceError{
+		Code:       40003,
+		Status:     "error",
+		Message:    "群聊不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrGroupMemberNotFound = &ServiceError{
+		Code:       40004,
+		Status:     "error",
+		Message:    "该用户不是群成员",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrGroupOwnerCannotLeave = &ServiceError{
+		Code:       40005,
+		Status:     "error",
+		Message:    "群主不能退出群聊",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrConversationNotFound = &ServiceError{
+		Code:       40006,
+		Status:     "error",
+		Message:    "会话不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrMessageNotFound = &ServiceError{
+		Code:       40007,
+		Status:     "error",
+		Message:    "消息不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrReplyTargetNotFound = &ServiceError{
+		Code:       40008,
+		Status:     "error",
+		Message:    "回复的消息不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrFriendRequestNotFound = &ServiceError{
+		Code:       40011,
+		Status:     "error",
+		Message:    "好友申请不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrInviteNotFound = &ServiceError{
+		Code:       40013,
+		Status:     "error",
+		Message:    "邀请链接不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrInviteUnavailable = &ServiceError{
+		Code:       40014,
+		Status:     "error",
+		Message:    "邀请链接已失效",
+		HTTPStatus: http.StatusGone,
+	}
+
+	ErrAlreadyGroupMember = &ServiceError{
+		Code:       40015,
+		Status:     "error",
+		Message:    "已经是群成员",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrJoinRequestPending = &ServiceError{
+		Code:       40016,
+		Status:     "error",
+		Message:    "已提交过入群申请，请等待管理员审批",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrJoinRequestNotFound = &ServiceError{
+		Code:       40017,
+		Status:     "error",
+		Message:    "入群申请不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrJoinRequestNotPending = &ServiceError{
+		Code:       40018,
+		Status:     "error",
+		Message:    "入群申请已处理",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrJoinRequestCooldown = &ServiceError{
+		Code:       40019,
+		Status:     "error",
+		Message:    "入群申请被拒绝后需要等待一段时间才能再次申请",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrGroupRoleNotFound = &ServiceError{
+		Code:       40020,
+		Status:     "error",
+		Message:    "角色不存在",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrGroupRoleConflict = &ServiceError{
+		Code:       40021,
+		Status:     "error",
+		Message:    "角色名称已存在",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrGroupBanNotFound = &ServiceError{
+		Code:       40022,
+		Status:     "error",
+		Message:    "用户未被禁止加入该群",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrGroupUserAlreadyBanned = &ServiceError{
+		Code:       40023,
+		Status:     "error",
+		Message:    "用户已被禁止加入该群",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrSessionNotFound = &ServiceError{
+		Code:       40024,
+		Status:     "error",
+		Message:    "登录会话不存在或已失效",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrTwoFactorAlreadyEnabled = &ServiceError{
+		Code:       40025,
+		Status:     "error",
+		Message:    "已开启两步验证",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrTwoFactorNotEnabled = &ServiceError{
+		Code:       40026,
+		Status:     "error",
+		Message:    "未开启两步验证",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrTwoFactorNotEnrolled = &ServiceError{
+		Code:       40027,
+		Status:     "error",
+		Message:    "请先获取两步验证密钥",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrEmailAlreadyVerified = &ServiceError{
+		Code:       40028,
+		Status:     "error",
+		Message:    "邮箱已验证，或尚未设置邮箱",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrEmailVerificationCooldown = &ServiceError{
+		Code:       40029,
+		Status:     "error",
+		Message:    "验证邮件发送过于频繁，请稍后再试",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrTwoFactorLocked = &ServiceError{
+		Code:       40030,
+		Status:     "error",
+		Message:    "验证码错误次数过多，请稍后再试",
+		HTTPStatus: http.StatusConflict,
+	}
+
 	// 500 Internal Server Error
 	ErrDatabaseFailed = &ServiceError{
 		Code:       10001,
@@ -188,6 +847,21 @@ var (
 		Message:    "系统繁忙，请稍后重试",
 		HTTPStatus: http.StatusInternalServerError,
 	}
+
+	ErrEmailSendFailed = &ServiceError{
+		Code:       10003,
+		Status:     "error",
+		Message:    "邮件发送失败，请稍后重试",
+		HTTPStatus: http.StatusInternalServerError,
+	}
+
+	// 429 Too Many Requests
+	ErrTooManyRequests = &ServiceError{
+		Code:       10002,
+		Status:     "error",
+		Message:    "请求过于频繁，请稍后重试",
+		HTTPStatus: http.StatusTooManyRequests,
+	}
 )
 
 // 用于存储校验错误消息
@@ -214,4 +888,131 @@ var ValidateErrorMessages = map[string]map[string]*ServiceError{
 	"email": {
 		"email": ErrEmailInvalid,
 	},
+	"content": {
+		"required": ErrMessageContentEmpty,
+		"max":      ErrMessageContentTooLong,
+	},
+	"type": {
+		"oneof": ErrMessageTypeInvalid,
+	},
+	"limit": {
+		"min": ErrLimitInvalid,
+		"max": ErrLimitInvalid,
+	},
+	"name": {
+		"required": ErrGroupNameEmpty,
+		"min":      ErrGroupNameEmpty,
+		"max":      ErrGroupNameTooLong,
+	},
+	"memberUuids": {
+		"required": ErrGroupMembersEmpty,
+		"min":      ErrGroupMembersEmpty,
+		"max":      ErrGroupMembersTooMany,
+	},
+	"page": {
+		"min": ErrPageInvalid,
+	},
+	"messageUuid": {
+		"required": ErrMessageUuidEmpty,
+	},
+	"conversationUuid": {
+		"required": ErrConversationUuidEmpty,
+	},
+	"receiverUuid": {
+		"required": ErrReceiverUuidEmpty,
+	},
+	"message": {
+		"max": ErrFriendRequestMessageTooLong,
+	},
+	"direction": {
+		"oneof": ErrDirectionInvalid,
+	},
+	"emailVisibility": {
+		"oneof": ErrVisibilityInvalid,
+	},
+	"avatarVisibility": {
+		"oneof": ErrVisibilityInvalid,
+	},
+	"lastSeenVisibility": {
+		"oneof": ErrVisibilityInvalid,
+	},
+	"bioVisibility": {
+		"oneof": ErrVisibilityInvalid,
+	},
+	"bio": {
+		"max": ErrBioTooLong,
+	},
+	"text": {
+		"max": ErrStatusTextTooLong,
+	},
+	"timezone": {
+		"timezone": ErrTimezoneInvalid,
+	},
+	"locale": {
+		"bcp47_language_tag": ErrLocaleInvalid,
+	},
+	"hashes": {
+		"required": ErrEmailHashesEmpty,
+		"min":      ErrEmailHashesEmpty,
+	},
+	"maxUses": {
+		"min": ErrInviteMaxUsesInvalid,
+		"max": ErrInviteMaxUsesInvalid,
+	},
+	"requestUuids": {
+		"required": ErrJoinRequestUuidsInvalid,
+		"min":      ErrJoinRequestUuidsInvalid,
+		"max":      ErrJoinRequestUuidsInvalid,
+	},
+	"action": {
+		"required": ErrReviewActionInvalid,
+		"oneof":    ErrReviewActionInvalid,
+	},
+	"role": {
+		"required": ErrGroupRoleNameInvalid,
+		"max":      ErrGroupRoleNameInvalid,
+	},
+	"permissions": {
+		"required": ErrGroupPermissionInvalid,
+	},
+	"reason": {
+		"max": ErrModerationReasonInvalid,
+	},
+	"duration": {
+		"required": ErrMuteDurationInvalid,
+		"min":      ErrMuteDurationInvalid,
+		"max":      ErrMuteDurationInvalid,
+	},
+	"refreshToken": {
+		"required": ErrInvalidRefreshToken,
+	},
+	"deviceName": {
+		"max": ErrDeviceNameTooLong,
+	},
+	"code": {
+		"required": ErrTwoFactorCodeInvalid,
+		"max":      ErrTwoFactorCodeInvalid,
+	},
+	"challengeToken": {
+		"required": ErrInvalidLoginChallenge,
+	},
+	"token": {
+		"required": ErrEmailVerificationInvalid,
+	},
+	"q": {
+		"required": ErrSearchQueryInvalid,
+		"max":      ErrSearchQueryInvalid,
+	},
+	"emoji": {
+		"required": ErrEmojiInvalid,
+		"max":      ErrEmojiInvalid,
+	},
+	"status": {
+		"required": ErrReceiptStatusInvalid,
+		"oneof":    ErrReceiptStatusInvalid,
+	},
+	"pageSize": {
+		"min": ErrPageSizeInvalid,
+		"max": ErrPageSizeInvalid,
+	},
 }
@@ -4,26 +4,36 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/shy-robin/gochat/config"
 )
 
 // Claims 定义了 JWT 的载荷信息
 type Claims struct {
+	// 包含 exp、iat、iss 以及 jti（ID 字段）
 	jwt.RegisteredClaims
 	UserId   string `json:"userId"`
 	Username string `json:"username"`
+	// Token 所属的登录会话
+	SessionId string `json:"sid"`
 }
 
-// GenerateToken 生成一个新的 JWT
-func GenerateToken(userId string, username string) (string, int64, error) {
+// 未配置 jwt.accessTokenTtl 时的默认值
+const defaultAccessTokenTtl = 15 * time.Minute
+
+// GenerateToken 为登录会话生成一个新的 JWT 作为 Access Token，有效期较短，过期后需要使用 Refresh Token 换取
+func GenerateToken(userId string, username string, sessionId string) (string, int64, error) {
 	jwtConfig := config.GetConfig().Jwt
 
-	expireTime := time.Now().Add(time.Duration(jwtConfig.ExpireTime) * time.Hour).Unix()
+	expireTime := time.Now().Add(accessTokenTtl()).Unix()
 
 	claims := &Claims{
-		UserId:   userId,
-		Username: username,
+		UserId:    userId,
+		Username:  username,
+		SessionId: sessionId,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// jti: Token 的唯一标识，用于退出登录后在服务端吊销 Token
+			ID: uuid.NewString(),
 			// exp: 设置过期时间 (必须使用 NewNumericDate)
 			ExpiresAt: jwt.NewNumericDate(time.Unix(expireTime, 0)),
 			// iat: 设置签发时间 (推荐设置)
@@ -39,7 +49,7 @@ func GenerateToken(userId string, username string) (string, int64, error) {
 	return tokenString, expireTime, err
 }
 
-// ValidateToken 验证 JWT 的有效性 (未在登录接口中使用，但用于后续接口)
+// ValidateToken 验证 JWT 的签名和有效期，是否已被吊销需要由调用方另外检查
 func ValidateToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 	jwtConfig := config.GetConfig().Jwt
@@ -63,3 +73,11 @@ func ValidateToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+func accessTokenTtl() time.Duration {
+	minutes := config.GetConfig().Jwt.AccessTokenTtl
+	if minutes <= 0 {
+		return defaultAccessTokenTtl
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -125,6 +125,29 @@ func WithSuccessListResponseMeta(meta any) SuccessListResponseOption {
 	}
 }
 
+// PageMeta 是基于页码分页的元数据
+type PageMeta struct {
+	Total    int64 `json:"total" example:"100"`
+	Page     int   `json:"page" example:"1"`
+	PageSize int   `json:"pageSize" example:"20"`
+}
+
+// CursorMeta 是基于游标分页的元数据
+type CursorMeta struct {
+	// 沿请求方向是否还有更多数据
+	HasMore bool `json:"hasMore" example:"true"`
+	// 沿请求方向继续翻页的游标，没有更多数据时为空
+	NextCursor string `json:"nextCursor,omitempty" example:"eyJ0IjoxNzYzOTExMTE5MDAwMDAwMDAwLCJpZCI6NDJ9"`
+	// 反方向翻页的游标
+	PrevCursor string `json:"prevCursor,omitempty" example:"eyJ0IjoxNzYzOTExMTE5MDAwMDAwMDAwLCJpZCI6NjF9"`
+}
+
+// CursorTotalMeta 是基于游标分页、同时返回总数的元数据
+type CursorTotalMeta struct {
+	Total int64 `json:"total" example:"100"`
+	CursorMeta
+}
+
 // SuccessList 响应列表成功，包含分页元数据
 func SuccessListResponse(ctx *gin.Context, opts ...SuccessListResponseOption) {
 	// 设置默认值
@@ -162,4 +185,9 @@ type PasswordSetter interface {
 	SetPassword()
 }
 
+// SecretMasker 定义了脱敏 Token 等敏感字段的方法，用于记录请求日志
+type SecretMasker interface {
+	MaskSecrets()
+}
+
 type EmptyRequest struct{}
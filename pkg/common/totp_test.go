@@ -0,0 +1,110 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录 B 中 SHA1 算法使用的密钥
+var rfc6238Key = []byte("12345678901234567890")
+
+func TestTotpCodeRfc6238Vectors(t *testing.T) {
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, c := range cases {
+		if got := totpCode(rfc6238Key, c.unix/totpPeriod); got != c.code {
+			t.Errorf("totpCode(%d) = %s, want %s", c.unix, got, c.code)
+		}
+	}
+}
+
+func TestValidateTotpWindow(t *testing.T) {
+	secret := totpEncoding.EncodeToString(rfc6238Key)
+	now := time.Unix(1234567890, 0)
+	current := now.Unix() / totpPeriod
+
+	cases := []struct {
+		name   string
+		offset int64
+		valid  bool
+	}{
+		{"previous step", -1, true},
+		{"current step", 0, true},
+		{"next step", 1, true},
+		{"two steps behind", -2, false},
+		{"two steps ahead", 2, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			code := totpCode(rfc6238Key, current+c.offset)
+			step, ok := ValidateTotp(secret, code, now)
+
+			if ok != c.valid {
+				t.Fatalf("ValidateTotp() ok = %v, want %v", ok, c.valid)
+			}
+			if ok && step != current+c.offset {
+				t.Errorf("ValidateTotp() step = %d, want %d", step, current+c.offset)
+			}
+		})
+	}
+}
+
+func TestValidateTotpRejectsMalformedInput(t *testing.T) {
+	secret := totpEncoding.EncodeToString(rfc6238Key)
+	now := time.Unix(1234567890, 0)
+	code := totpCode(rfc6238Key, now.Unix()/totpPeriod)
+
+	cases := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{"empty code", secret, ""},
+		{"short code", secret, code[:5]},
+		{"long code", secret, code + "0"},
+		{"invalid secret", "not base32!", code},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, ok := ValidateTotp(c.secret, c.code, now); ok {
+				t.Errorf("ValidateTotp(%q, %q) ok = true, want false", c.secret, c.code)
+			}
+		})
+	}
+}
+
+func TestValidateTotpAcceptsLowercaseSecret(t *testing.T) {
+	secret := strings.ToLower(totpEncoding.EncodeToString(rfc6238Key))
+	now := time.Unix(59, 0)
+
+	if _, ok := ValidateTotp(secret, "287082", now); !ok {
+		t.Error("ValidateTotp() with lowercase secret ok = false, want true")
+	}
+}
+
+func TestGenerateTotpSecret(t *testing.T) {
+	secret, err := GenerateTotpSecret()
+	if err != nil {
+		t.Fatalf("GenerateTotpSecret() error = %v", err)
+	}
+
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret %q is not base32: %v", secret, err)
+	}
+	if len(key) != totpSecretSize {
+		t.Errorf("secret length = %d bytes, want %d", len(key), totpSecretSize)
+	}
+}